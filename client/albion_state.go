@@ -1,8 +1,8 @@
 package client
 
 import (
-	"strings"
-	"regexp"
+	"sync/atomic"
+	"time"
 
 	"github.com/ao-data/albiondata-client/lib"
 	"github.com/ao-data/albiondata-client/log"
@@ -12,15 +12,19 @@ import (
 // CacheSize limit size of messages in cache
 const CacheSize = 8192
 
+// How long a response waits for the request it answers
+const requestWait = 30 * time.Second
+
 type marketHistoryInfo struct {
 	albionId  int32
 	timescale lib.Timescale
 	quality   uint8
 }
 
-type albionState struct {
-	LocationId           string
-	LocationString       string
+// stateSnapshot is an immutable copy of the player state. Handlers read from
+// it, changes go through albionState.update.
+type stateSnapshot struct {
+	Location             lib.Location
 	CharacterId          lib.CharacterID
 	CharacterName        string
 	GameServerIP         string
@@ -28,32 +32,154 @@ type albionState struct {
 	AODataIngestBaseURL  string
 	WaitingForMarketData bool
 
+	// The game server named by the login or cluster handshake. Unlike the
+	// packet source it is the real server behind a proxy or VPN.
+	AnnouncedServerIP string
+
+	// Session identifies the game connection the state belongs to, empty
+	// for messages outside of a game connection
+	Session string
+
+	// When the payload being uploaded was captured, set by the upload queue
+	CapturedAt time.Time `json:"-"`
+}
+
+// stateChange is a command applied to the state by its owning goroutine
+type stateChange struct {
+	apply func(*stateSnapshot)
+	done  chan struct{}
+}
+
+// albionState owns the player state of one game session. A single goroutine
+// applies every change to a copy of the current snapshot and then publishes
+// it, so readers never see a half written state and never need a lock.
+type albionState struct {
+	current atomic.Pointer[stateSnapshot]
+	changes chan stateChange
+	stopped chan struct{}
+
 	// A lot of information is sent out but not contained in the response when requesting marketHistory (e.g. ID)
-	// This information is stored in marketHistoryInfo
-	// This array acts as a type of cache for that info
-	// The index is the message number (param255) % CacheSize
-	marketHistoryIDLookup [CacheSize]marketHistoryInfo
-	// TODO could this be improved?!
+	// This information is stored in marketHistoryInfo until the response arrives
+	marketHistoryRequests *correlator[marketHistoryInfo]
+
+	// What was asked in a market search, logged with the response
+	auctionOffersRequests *correlator[operationAuctionGetOffers]
+
+	// Where the state is saved across restarts, if anywhere
+	sessionFile *sessionFile
 }
 
-func (state albionState) IsValidLocation() bool {
-	var onlydigits = regexp.MustCompile(`^[0-9]+$`)
+// newAlbionState starts a state from initial, changes are saved to file if
+// it is not nil
+func newAlbionState(initial stateSnapshot, file *sessionFile) *albionState {
+	state := &albionState{
+		changes:               make(chan stateChange, 100),
+		stopped:               make(chan struct{}),
+		marketHistoryRequests: newCorrelator[marketHistoryInfo](correlationTTL, CacheSize),
+		auctionOffersRequests: newCorrelator[operationAuctionGetOffers](correlationTTL, CacheSize),
+		sessionFile:           file,
+	}
+	state.current.Store(&initial)
+
+	go state.run()
+
+	return state
+}
+
+func (state *albionState) run() {
+	for {
+		select {
+		case <-state.stopped:
+			return
+		case change := <-state.changes:
+			prev := *state.current.Load()
+			next := prev
+			change.apply(&next)
+			state.current.Store(&next)
+
+			if state.sessionFile != nil && persistedPlayerChanged(prev, next) {
+				state.sessionFile.savePlayer(next)
+			}
+			close(change.done)
+		}
+	}
+}
+
+// stop ends the goroutine owning the state, later changes are ignored
+func (state *albionState) stop() {
+	close(state.stopped)
+}
+
+// snapshot returns the current state. It does not change when the state does.
+func (state *albionState) snapshot() stateSnapshot {
+	return *state.current.Load()
+}
 
+// update applies a change to the state and waits until it is visible
+func (state *albionState) update(apply func(*stateSnapshot)) {
+	change := stateChange{apply: apply, done: make(chan struct{})}
+	select {
+	case state.changes <- change:
+		<-change.done
+	case <-state.stopped:
+	}
+}
+
+// setGameServer records the source of the packets the game server sends and
+// the realm it belongs to. It runs for every packet, so the state is only
+// updated when the server or its realm changed.
+func (state *albionState) setGameServer(ip string) {
+	current := state.snapshot()
+	next := current
+	next.GameServerIP = ip
+	next.AODataServerID, next.AODataIngestBaseURL = next.GetServer()
+	if next.GameServerIP == current.GameServerIP &&
+		next.AODataServerID == current.AODataServerID &&
+		next.AODataIngestBaseURL == current.AODataIngestBaseURL {
+		return
+	}
+
+	state.update(func(s *stateSnapshot) {
+		s.GameServerIP = ip
+		s.AODataServerID, s.AODataIngestBaseURL = s.GetServer()
+	})
+
+	// behind a proxy the source is not a game server, the handshake told us
+	if _, announced := realms.lookup(current.AnnouncedServerIP); current.Session != "" && !announced {
+		realms.reportUnknown(ip)
+	}
+}
+
+// announceGameServer records the game server named in a handshake and takes
+// the realm from it
+func (state *albionState) announceGameServer(ip string, source string) {
+	realms.reportUnknown(ip)
+
+	state.update(func(s *stateSnapshot) {
+		if s.AnnouncedServerIP != ip {
+			log.Debugf("The %v handshake names game server %v", source, ip)
+		}
+		s.AnnouncedServerIP = ip
+		s.AODataServerID, s.AODataIngestBaseURL = s.GetServer()
+	})
+}
+
+func (state *albionState) setWaitingForMarketData(waiting bool) {
+	state.update(func(s *stateSnapshot) {
+		s.WaitingForMarketData = waiting
+	})
+}
+
+func (state stateSnapshot) IsValidLocation() bool {
 	switch {
-	case state.LocationId == "":
+	case state.Location.ID == "":
 		log.Error("The players location has not yet been set. Please transition zones so the location can be identified.")
 		if !ConfigGlobal.Debug {
 			notification.Push("The players location has not yet been set. Please transition zones so the location can be identified.")
 		}
 		return false
 
-	case onlydigits.MatchString(state.LocationId):
-		return true
-	case strings.HasPrefix(state.LocationId, "BLACKBANK-"):
-		return true
-	case strings.HasSuffix(state.LocationId, "-HellDen"):
-		return true
-	case strings.HasSuffix(state.LocationId, "-Auction2"):
+	case state.Location.IsMarket():
 		return true
 	default:
 		log.Error("The players location is not valid. Please transition zones so the location can be fixed.")
@@ -64,7 +190,19 @@ func (state albionState) IsValidLocation() bool {
 	}
 }
 
-func (state albionState) GetServer() (int, string) {
+// realm is the realm of the game server the player is on
+func (state stateSnapshot) realm() (realm, bool) {
+	// the server named in the handshake wins, the source of the packets is
+	// only a fallback as it may be a proxy
+	if r, ok := realms.lookup(state.AnnouncedServerIP); ok {
+		return r, true
+	}
+	// we get packets from other than game servers, so determine if it's a game server
+	// based on source ip and the realm ranges
+	return realms.lookup(state.GameServerIP)
+}
+
+func (state stateSnapshot) GetServer() (int, string) {
 	// default to 0
 	var serverID = 0
 	var AODataIngestBaseURL = ""
@@ -77,30 +215,16 @@ func (state albionState) GetServer() (int, string) {
 		AODataIngestBaseURL = state.AODataIngestBaseURL
 	}
 
-	// we get packets from other than game servers, so determine if it's a game server
-	// based on soruce ip and if its east/west servers
-	var isAlbionIP = false
-	if strings.HasPrefix(state.GameServerIP, "5.188.125.") {
-		// west server class c ip range
-		serverID = 1
-		isAlbionIP = true
-		AODataIngestBaseURL = "https+pow://pow.west.albion-online-data.com"
-	} else if strings.HasPrefix(state.GameServerIP, "5.45.187.") {
-		// east server class c ip range
-		isAlbionIP = true
-		serverID = 2
-		AODataIngestBaseURL = "https+pow://pow.east.albion-online-data.com"
-	} else if strings.HasPrefix(state.GameServerIP, "193.169.238.") {
-		// eu server class c ip range
-		isAlbionIP = true
-		serverID = 3
-		AODataIngestBaseURL = "https+pow://pow.europe.albion-online-data.com"
+	realm, isAlbionIP := state.realm()
+	if isAlbionIP {
+		serverID = realm.ID
+		AODataIngestBaseURL = realm.IngestURL
 	}
 
 	// if this was a known albion online server ip, then let's log it
 	if isAlbionIP {
-		log.Tracef("Returning Server ID %v (ip src: %v)", serverID, state.GameServerIP)
-		log.Tracef("Returning AODataIngestBaseURL %v (ip src: %v)", AODataIngestBaseURL, state.GameServerIP)
+		log.Tracef("Returning Server ID %v (ip src: %v, announced: %v)", serverID, state.GameServerIP, state.AnnouncedServerIP)
+		log.Tracef("Returning AODataIngestBaseURL %v (ip src: %v, announced: %v)", AODataIngestBaseURL, state.GameServerIP, state.AnnouncedServerIP)
 	}
 
 	return serverID, AODataIngestBaseURL
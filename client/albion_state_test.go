@@ -0,0 +1,60 @@
+package client
+
+import (
+	"net"
+	"testing"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+func udpPacket(t *testing.T, src string, srcPort, dstPort layers.UDPPort) gopacket.Packet {
+	t.Helper()
+
+	ip := &layers.IPv4{
+		Version:  4,
+		TTL:      64,
+		Protocol: layers.IPProtocolUDP,
+		SrcIP:    net.ParseIP(src),
+		DstIP:    net.ParseIP("192.168.1.20"),
+	}
+	udp := &layers.UDP{SrcPort: srcPort, DstPort: dstPort}
+	udp.SetNetworkLayerForChecksum(ip)
+
+	buf := gopacket.NewSerializeBuffer()
+	opts := gopacket.SerializeOptions{FixLengths: true, ComputeChecksums: true}
+	if err := gopacket.SerializeLayers(buf, opts, ip, udp, gopacket.Payload{0, 0}); err != nil {
+		t.Fatal(err)
+	}
+	return gopacket.NewPacket(buf.Bytes(), layers.LayerTypeIPv4, gopacket.Default)
+}
+
+func TestFromGameServer(t *testing.T) {
+	if !fromGameServer(udpPacket(t, "5.188.125.40", gameServerPort, 55123)) {
+		t.Error("a packet from port 5056 was not taken as from the game server")
+	}
+	if fromGameServer(udpPacket(t, "192.168.1.20", 55123, gameServerPort)) {
+		t.Error("a packet to port 5056 was taken as from the game server")
+	}
+}
+
+func TestSetGameServerSkipsUnchanged(t *testing.T) {
+	state := newAlbionState(stateSnapshot{}, nil)
+	defer state.stop()
+
+	state.setGameServer("5.188.125.40")
+	if got := state.snapshot().GameServerIP; got != "5.188.125.40" {
+		t.Fatalf("GameServerIP = %q, want 5.188.125.40", got)
+	}
+
+	before := state.current.Load()
+	state.setGameServer("5.188.125.40")
+	if state.current.Load() != before {
+		t.Error("the same game server updated the state again")
+	}
+
+	state.setGameServer("5.188.125.41")
+	if got := state.snapshot().GameServerIP; got != "5.188.125.41" {
+		t.Errorf("GameServerIP = %q, want 5.188.125.41", got)
+	}
+}
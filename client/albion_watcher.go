@@ -30,6 +30,9 @@ func (apw *albionProcessWatcher) run() error {
 	}
 	apw.devices = physicalInterfaces
 	log.Debugf("Will listen to these devices: %v", apw.devices)
+	if ConfigGlobal.StatePath != "" {
+		apw.r.sessions.restore(newSessionFile(ConfigGlobal.StatePath))
+	}
 	go apw.r.run()
 
 	for {
@@ -48,6 +51,7 @@ func (apw *albionProcessWatcher) run() error {
 
 func (apw *albionProcessWatcher) closeWatcher() {
 	log.Print("Albion watcher closed")
+	logUnhandledCodes()
 
 	for port := range apw.listeners {
 		for _, l := range apw.listeners[port] {
@@ -57,7 +61,8 @@ func (apw *albionProcessWatcher) closeWatcher() {
 		delete(apw.listeners, port)
 	}
 
-	apw.r.quit <- true
+	apw.r.stop()
+	closeDispatcher(0)
 }
 
 func (apw *albionProcessWatcher) createListeners() {
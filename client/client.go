@@ -1,8 +1,6 @@
 package client
 
 import (
-	"time"
-
 	"github.com/ao-data/albiondata-client/log"
 )
 
@@ -24,6 +22,28 @@ func (client *Client) Run() error {
 	log.Info("This is a third-party application and is in no way affiliated with Sandbox Interactive or Albion Online.")
 	log.Info("Additional parameters can listed by calling this file with the -h parameter.")
 
+	if ConfigGlobal.CodeTablePath != "" {
+		if err := loadCodeTable(ConfigGlobal.CodeTablePath); err != nil {
+			return err
+		}
+	}
+	log.Infof("Using code table version %v (%d operations, %d events)", codes.Version, len(codes.Operations), len(codes.Events))
+
+	if len(ConfigGlobal.Realms) > 0 {
+		if err := loadRealms(ConfigGlobal.Realms); err != nil {
+			return err
+		}
+	}
+	for _, r := range realms.realms {
+		log.Debugf("Realm %v (%d): %v, sending to %v", r.Name, r.ID, r.Ranges, r.IngestURL)
+	}
+
+	handlers.checkCodeTable(codes)
+	log.Debugf("Handling requests: %v", handlers.names(kindRequest))
+	log.Debugf("Handling responses: %v", handlers.names(kindResponse))
+	log.Debugf("Handling events: %v", handlers.names(kindEvent))
+	drift = newDriftDetector(handlers)
+
 	ConfigGlobal.setupDebugEvents()
 	ConfigGlobal.setupDebugOperations()
 
@@ -31,14 +51,7 @@ func (client *Client) Run() error {
 
 	if ConfigGlobal.Offline {
 		processOffline(ConfigGlobal.OfflinePath)
-
-		// TODO: get rid of this, some kind of stupid delay locally when hitting /pow on local dev server, fix it
-		n := 1
-		for n < 5000 {
-			time.Sleep(1 * time.Millisecond)
-			n++
-		}
-
+		closeDispatcher(offlineUploadWait)
 	} else {
 		apw := newAlbionProcessWatcher()
 		return apw.run()
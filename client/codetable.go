@@ -0,0 +1,104 @@
+package client
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// The default code table shipped with the client. After a game patch shifts
+// the codes, a fixed table can be loaded from disk with -codes (or CodeTable
+// in config.yaml) without rebuilding.
+//
+//go:embed data/codes.json
+var defaultCodeTable []byte
+
+// codeTable maps the symbolic operation and event names to the numeric codes
+// the game currently uses. The position of a name in its list is its code.
+type codeTable struct {
+	Version    string   `json:"version"`
+	Operations []string `json:"operations"`
+	Events     []string `json:"events"`
+
+	operationCodes map[string]OperationType
+	eventCodes     map[string]EventType
+}
+
+// codes is the code table in use
+var codes = mustParseCodeTable(defaultCodeTable, "embedded")
+
+func parseCodeTable(data []byte) (*codeTable, error) {
+	table := &codeTable{}
+	if err := json.Unmarshal(data, table); err != nil {
+		return nil, err
+	}
+
+	table.operationCodes = make(map[string]OperationType, len(table.Operations))
+	for code, name := range table.Operations {
+		if _, exists := table.operationCodes[name]; exists {
+			return nil, fmt.Errorf("duplicate operation name %q", name)
+		}
+		table.operationCodes[name] = OperationType(code)
+	}
+
+	table.eventCodes = make(map[string]EventType, len(table.Events))
+	for code, name := range table.Events {
+		if _, exists := table.eventCodes[name]; exists {
+			return nil, fmt.Errorf("duplicate event name %q", name)
+		}
+		table.eventCodes[name] = EventType(code)
+	}
+
+	return table, nil
+}
+
+func mustParseCodeTable(data []byte, source string) *codeTable {
+	table, err := parseCodeTable(data)
+	if err != nil {
+		panic(fmt.Sprintf("invalid %s code table: %v", source, err))
+	}
+	return table
+}
+
+// loadCodeTable replaces the code table in use with the one at path
+func loadCodeTable(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	table, err := parseCodeTable(data)
+	if err != nil {
+		return fmt.Errorf("invalid code table %v: %v", path, err)
+	}
+
+	codes = table
+	return nil
+}
+
+// operation returns the code currently assigned to the named operation
+func (t *codeTable) operation(name string) (OperationType, bool) {
+	code, ok := t.operationCodes[name]
+	return code, ok
+}
+
+// event returns the code currently assigned to the named event
+func (t *codeTable) event(name string) (EventType, bool) {
+	code, ok := t.eventCodes[name]
+	return code, ok
+}
+
+func (t *codeTable) operationName(code OperationType) string {
+	if int(code) < len(t.Operations) {
+		return t.Operations[code]
+	}
+	return ""
+}
+
+func (t *codeTable) eventName(code EventType) string {
+	if int(code) < len(t.Events) {
+		return t.Events[code]
+	}
+	return ""
+}
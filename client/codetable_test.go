@@ -0,0 +1,101 @@
+package client
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestEmbeddedCodeTable(t *testing.T) {
+	table := mustParseCodeTable(defaultCodeTable, "embedded")
+	if len(table.Operations) != 533 || len(table.Events) != 662 {
+		t.Errorf("the table has %d operations and %d events, want 533 and 662", len(table.Operations), len(table.Events))
+	}
+
+	for name, want := range map[string]OperationType{
+		"Join":                       2,
+		"AuctionGetOffers":           75,
+		"AuctionGetRequests":         76,
+		"AuctionBuyOffer":            77,
+		"AuctionGetItemAverageStats": 89,
+		"GetMailInfos":               168,
+		"GoldMarketGetAverageInfo":   244,
+	} {
+		if code, ok := table.operation(name); !ok || code != want {
+			t.Errorf("operation %v = %d, %v, want %d", name, code, ok, want)
+		}
+		if got := table.operationName(want); got != name {
+			t.Errorf("operation %d is named %q, want %v", want, got, name)
+		}
+	}
+	if code, ok := table.event("Respawn"); !ok || code != 88 {
+		t.Errorf("event Respawn = %d, %v, want 88", code, ok)
+	}
+	if _, ok := table.operation("NoSuchOperation"); ok {
+		t.Error("an unknown operation has a code")
+	}
+	if got := table.operationName(OperationType(len(table.Operations))); got != "" {
+		t.Errorf("a code past the table is named %q", got)
+	}
+}
+
+// A handler named after something missing from the table is never called
+func TestHandlersAreInTheCodeTable(t *testing.T) {
+	table := mustParseCodeTable(defaultCodeTable, "embedded")
+	for _, kind := range []messageKind{kindRequest, kindResponse, kindEvent} {
+		for _, name := range handlers.names(kind) {
+			var found bool
+			if kind == kindEvent {
+				_, found = table.event(name)
+			} else {
+				_, found = table.operation(name)
+			}
+			if !found {
+				t.Errorf("the %v handler %v is not in the code table", kind, name)
+			}
+		}
+	}
+}
+
+func TestLoadCodeTable(t *testing.T) {
+	saved := codes
+	defer func() { codes = saved }()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "codes.json")
+	// a patch inserted an operation before Join
+	if err := os.WriteFile(path, []byte(`{"version":"2","operations":["Unused","Ping","New","Join"],"events":["Leave"]}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := loadCodeTable(path); err != nil {
+		t.Fatal(err)
+	}
+	if got := OperationType(3).String(); got != "Join" {
+		t.Errorf("operation 3 is %q, want Join", got)
+	}
+	if got := OperationType(4).String(); got != "OperationType(4)" {
+		t.Errorf("operation 4 is %q, want it unnamed", got)
+	}
+	if got := EventType(0).String(); got != "Leave" {
+		t.Errorf("event 0 is %q, want Leave", got)
+	}
+
+	for name, data := range map[string]string{
+		"duplicate": `{"operations":["Join","Join"]}`,
+		"broken":    `{"operations":`,
+	} {
+		bad := filepath.Join(dir, name+".json")
+		if err := os.WriteFile(bad, []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := loadCodeTable(bad); err == nil {
+			t.Errorf("the %v table was loaded", name)
+		}
+	}
+	if err := loadCodeTable(filepath.Join(dir, "missing.json")); err == nil {
+		t.Error("a missing table was loaded")
+	}
+	if got := OperationType(3).String(); got != "Join" {
+		t.Errorf("a table that failed to load replaced the one in use, operation 3 is %q", got)
+	}
+}
@@ -0,0 +1,31 @@
+package client
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+)
+
+// compressBody compresses an upload body for the compression option of an
+// HTTP target, the request is sent with it as Content-Encoding:
+//
+//	https+pow://albion-online-data.com?compression=gzip
+func compressBody(compression string, body []byte) ([]byte, error) {
+	switch compression {
+	case "":
+		return body, nil
+	case "gzip":
+		var b bytes.Buffer
+		w := gzip.NewWriter(&b)
+		if _, err := w.Write(body); err != nil {
+			return nil, err
+		}
+		if err := w.Close(); err != nil {
+			return nil, err
+		}
+		return b.Bytes(), nil
+	case "zstd":
+		return zstdCompress(body), nil
+	}
+	return nil, fmt.Errorf("unknown compression %q", compression)
+}
@@ -0,0 +1,102 @@
+package client
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"math/rand"
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+func compressionInputs() map[string][]byte {
+	var orders strings.Builder
+	for i := 0; orders.Len() < 3*zstdBlockSize; i++ {
+		fmt.Fprintf(&orders, `{"Id":%d,"ItemTypeId":"T4_BAG","LocationId":"3005","QualityLevel":1,"UnitPriceSilver":%d,"Amount":1,"AuctionType":"offer"},`, 100000+i, 1000*(i%50))
+	}
+
+	rng := rand.New(rand.NewSource(1))
+	random := make([]byte, 2*zstdBlockSize+123)
+	rng.Read(random)
+	// short matches at every distance
+	mixed := make([]byte, zstdBlockSize+99)
+	for i := range mixed {
+		mixed[i] = "ACGT"[rng.Intn(4)]
+	}
+
+	return map[string][]byte{
+		"empty":          {},
+		"small":          []byte(`{"Prices":[1],"TimeStamps":[2]}`),
+		"repetitive":     bytes.Repeat([]byte("a"), 3*zstdBlockSize+7),
+		"orders":         []byte(orders.String()),
+		"mixed":          mixed,
+		"incompressible": random,
+	}
+}
+
+// The frames are checked with the reference decoder, there is none in Go
+// without a dependency
+func TestZstdRoundTrip(t *testing.T) {
+	zstd, err := exec.LookPath("zstd")
+	if err != nil {
+		t.Skip("the zstd command is needed to decode the frames")
+	}
+
+	for name, input := range compressionInputs() {
+		t.Run(name, func(t *testing.T) {
+			compressed, err := compressBody("zstd", input)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			cmd := exec.Command(zstd, "--decompress", "--stdout")
+			cmd.Stdin = bytes.NewReader(compressed)
+			var stderr bytes.Buffer
+			cmd.Stderr = &stderr
+			output, err := cmd.Output()
+			if err != nil {
+				t.Fatalf("zstd could not decode the frame: %v: %s", err, stderr.String())
+			}
+			if !bytes.Equal(output, input) {
+				t.Fatalf("decoded %d bytes, want the %d compressed", len(output), len(input))
+			}
+
+			switch name {
+			case "repetitive", "orders":
+				if len(compressed) > len(input)/4 {
+					t.Errorf("compressed %d bytes to %d", len(input), len(compressed))
+				}
+			case "incompressible":
+				// stored raw, with only the headers on top
+				if len(compressed) > len(input)+64 {
+					t.Errorf("compressed %d bytes to %d", len(input), len(compressed))
+				}
+			}
+		})
+	}
+}
+
+func TestGzipRoundTrip(t *testing.T) {
+	for name, input := range compressionInputs() {
+		compressed, err := compressBody("gzip", input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		r, err := gzip.NewReader(bytes.NewReader(compressed))
+		if err != nil {
+			t.Fatalf("%v: %v", name, err)
+		}
+		output, err := io.ReadAll(r)
+		if err != nil || !bytes.Equal(output, input) {
+			t.Errorf("%v: decoded %d bytes (%v), want the %d compressed", name, len(output), err, len(input))
+		}
+	}
+}
+
+func TestUnknownCompression(t *testing.T) {
+	if _, err := compressBody("brotli", []byte("{}")); err == nil {
+		t.Error("an unknown compression was accepted")
+	}
+}
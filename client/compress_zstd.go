@@ -0,0 +1,343 @@
+package client
+
+import (
+	"encoding/binary"
+	"math/bits"
+)
+
+// A small zstd encoder (RFC 8878), as no zstd library is vendored. It finds
+// matches greedily through a hash table and codes the sequences with the
+// predefined FSE tables. The literals are stored raw, which costs some ratio
+// on top of a full encoder but is still far smaller than the JSON.
+
+const (
+	zstdMagic     = 0xFD2FB528
+	zstdBlockSize = 128 << 10
+	zstdHashLog   = 16
+	zstdMinMatch  = 4
+)
+
+// block types
+const (
+	zstdBlockRaw        = 0
+	zstdBlockCompressed = 2
+)
+
+var (
+	zstdLLBaselines = [...]uint32{
+		0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15,
+		16, 18, 20, 22, 24, 28, 32, 40, 48, 64, 128, 256, 512, 1024, 2048, 4096,
+		8192, 16384, 32768, 65536,
+	}
+	zstdLLBits = [...]uint8{
+		0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+		1, 1, 1, 1, 2, 2, 3, 3, 4, 6, 7, 8, 9, 10, 11, 12,
+		13, 14, 15, 16,
+	}
+	zstdMLBaselines = [...]uint32{
+		3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18,
+		19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 31, 32, 33, 34,
+		35, 37, 39, 41, 43, 47, 51, 59, 67, 83, 99, 131, 259, 515, 1027, 2051,
+		4099, 8195, 16387, 32771, 65539,
+	}
+	zstdMLBits = [...]uint8{
+		0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+		0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+		1, 1, 1, 1, 2, 2, 3, 3, 4, 4, 5, 7, 8, 9, 10, 11,
+		12, 13, 14, 15, 16,
+	}
+
+	// the predefined distributions
+	zstdLLEncoder = newFSEEncoder([]int16{
+		4, 3, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 1, 1, 1,
+		2, 2, 2, 2, 2, 2, 2, 2, 2, 3, 2, 1, 1, 1, 1, 1,
+		-1, -1, -1, -1,
+	}, 6)
+	zstdMLEncoder = newFSEEncoder([]int16{
+		1, 4, 3, 2, 2, 2, 2, 2, 2, 1, 1, 1, 1, 1, 1, 1,
+		1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1,
+		1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, -1, -1,
+		-1, -1, -1, -1, -1,
+	}, 6)
+	zstdOFEncoder = newFSEEncoder([]int16{
+		1, 1, 1, 1, 1, 1, 2, 2, 2, 1, 1, 1, 1, 1, 1, 1,
+		1, 1, 1, 1, 1, 1, 1, 1, -1, -1, -1, -1, -1,
+	}, 5)
+)
+
+// zstdCompress compresses src into one zstd frame
+func zstdCompress(src []byte) []byte {
+	out := binary.LittleEndian.AppendUint32(nil, zstdMagic)
+	// single segment, the content size in 4 or 8 bytes, no checksum
+	if uint64(len(src)) <= 0xFFFFFFFF {
+		out = append(out, 2<<6|1<<5)
+		out = binary.LittleEndian.AppendUint32(out, uint32(len(src)))
+	} else {
+		out = append(out, 3<<6|1<<5)
+		out = binary.LittleEndian.AppendUint64(out, uint64(len(src)))
+	}
+
+	table := make([]int32, 1<<zstdHashLog)
+	start := 0
+	for {
+		end := start + zstdBlockSize
+		if end > len(src) {
+			end = len(src)
+		}
+		last := end == len(src)
+
+		block := zstdCompressBlock(src, start, end, table)
+		if block == nil || len(block) >= end-start {
+			out = zstdBlockHeader(out, last, zstdBlockRaw, end-start)
+			out = append(out, src[start:end]...)
+		} else {
+			out = zstdBlockHeader(out, last, zstdBlockCompressed, len(block))
+			out = append(out, block...)
+		}
+
+		if last {
+			return out
+		}
+		start = end
+	}
+}
+
+func zstdBlockHeader(out []byte, last bool, kind int, size int) []byte {
+	header := uint32(size)<<3 | uint32(kind)<<1
+	if last {
+		header |= 1
+	}
+	return append(out, byte(header), byte(header>>8), byte(header>>16))
+}
+
+type zstdSequence struct {
+	literals int
+	match    int
+	offset   int
+}
+
+// zstdCompressBlock codes src[start:end], matches may reach back to the
+// start of the frame. It returns nil when nothing matched.
+func zstdCompressBlock(src []byte, start, end int, table []int32) []byte {
+	var sequences []zstdSequence
+	var literals []byte
+
+	anchor := start
+	for i := start; i+zstdMinMatch <= end; {
+		h := zstdHash(src[i:])
+		candidate := int(table[h]) - 1
+		table[h] = int32(i + 1)
+
+		if candidate < 0 || binary.LittleEndian.Uint32(src[candidate:]) != binary.LittleEndian.Uint32(src[i:]) {
+			i++
+			continue
+		}
+
+		match := zstdMinMatch
+		for i+match < end && src[candidate+match] == src[i+match] {
+			match++
+		}
+		sequences = append(sequences, zstdSequence{literals: i - anchor, match: match, offset: i - candidate})
+		literals = append(literals, src[anchor:i]...)
+
+		i += match
+		anchor = i
+	}
+	if len(sequences) == 0 {
+		return nil
+	}
+	literals = append(literals, src[anchor:end]...)
+
+	out := zstdLiteralsHeader(nil, len(literals))
+	out = append(out, literals...)
+	return zstdEncodeSequences(out, sequences)
+}
+
+func zstdHash(b []byte) uint32 {
+	return (binary.LittleEndian.Uint32(b) * 2654435761) >> (32 - zstdHashLog)
+}
+
+// zstdLiteralsHeader starts a raw literals section
+func zstdLiteralsHeader(out []byte, size int) []byte {
+	switch {
+	case size < 32:
+		return append(out, byte(size<<3))
+	case size < 4096:
+		return append(out, byte(1<<2|(size&0xF)<<4), byte(size>>4))
+	default:
+		return append(out, byte(3<<2|(size&0xF)<<4), byte(size>>4), byte(size>>12))
+	}
+}
+
+func zstdEncodeSequences(out []byte, sequences []zstdSequence) []byte {
+	n := len(sequences)
+	switch {
+	case n < 128:
+		out = append(out, byte(n))
+	case n < 0x7F00:
+		out = append(out, byte(n>>8)+0x80, byte(n))
+	default:
+		out = append(out, 0xFF, byte(n-0x7F00), byte((n-0x7F00)>>8))
+	}
+	// predefined modes for the literal lengths, offsets and match lengths
+	out = append(out, 0)
+
+	llCodes := make([]uint8, n)
+	mlCodes := make([]uint8, n)
+	ofCodes := make([]uint8, n)
+	for i, s := range sequences {
+		llCodes[i] = zstdCode(zstdLLBaselines[:], uint32(s.literals))
+		mlCodes[i] = zstdCode(zstdMLBaselines[:], uint32(s.match))
+		// offsets above 3, the repeat codes are not used
+		ofCodes[i] = uint8(bits.Len32(uint32(s.offset+3)) - 1)
+	}
+
+	// the decoder reads backwards, so the last sequence goes first
+	w := &zstdBitWriter{out: out}
+	last := n - 1
+	ml := zstdMLEncoder.init(mlCodes[last])
+	of := zstdOFEncoder.init(ofCodes[last])
+	ll := zstdLLEncoder.init(llCodes[last])
+	w.addSequenceBits(sequences[last], llCodes[last], mlCodes[last], ofCodes[last])
+
+	for i := last - 1; i >= 0; i-- {
+		of = zstdOFEncoder.encode(w, of, ofCodes[i])
+		ml = zstdMLEncoder.encode(w, ml, mlCodes[i])
+		ll = zstdLLEncoder.encode(w, ll, llCodes[i])
+		w.addSequenceBits(sequences[i], llCodes[i], mlCodes[i], ofCodes[i])
+	}
+
+	w.add(uint64(ml), uint(zstdMLEncoder.tableLog))
+	w.add(uint64(of), uint(zstdOFEncoder.tableLog))
+	w.add(uint64(ll), uint(zstdLLEncoder.tableLog))
+	return w.close()
+}
+
+// zstdCode is the code of a value: the last one whose baseline it reaches
+func zstdCode(baselines []uint32, value uint32) uint8 {
+	code := 0
+	for code+1 < len(baselines) && baselines[code+1] <= value {
+		code++
+	}
+	return uint8(code)
+}
+
+// zstdBitWriter writes a bit stream from the low bits up
+type zstdBitWriter struct {
+	out  []byte
+	acc  uint64
+	used uint
+}
+
+func (w *zstdBitWriter) add(value uint64, n uint) {
+	w.acc |= (value & (1<<n - 1)) << w.used
+	w.used += n
+	for w.used >= 8 {
+		w.out = append(w.out, byte(w.acc))
+		w.acc >>= 8
+		w.used -= 8
+	}
+}
+
+func (w *zstdBitWriter) addSequenceBits(s zstdSequence, llCode, mlCode, ofCode uint8) {
+	w.add(uint64(uint32(s.literals)-zstdLLBaselines[llCode]), uint(zstdLLBits[llCode]))
+	w.add(uint64(uint32(s.match)-zstdMLBaselines[mlCode]), uint(zstdMLBits[mlCode]))
+	w.add(uint64(s.offset+3), uint(ofCode))
+}
+
+// close ends the stream with a set bit, the decoder finds the start by it
+func (w *zstdBitWriter) close() []byte {
+	w.add(1, 1)
+	if w.used > 0 {
+		w.out = append(w.out, byte(w.acc))
+	}
+	return w.out
+}
+
+// fseEncoder codes symbols with a finite state entropy table, built like the
+// reference encoder so it mirrors the decoding table of RFC 8878
+type fseEncoder struct {
+	tableLog   uint8
+	stateTable []uint16
+	symbols    []fseSymbol
+}
+
+type fseSymbol struct {
+	deltaNbBits    uint32
+	deltaFindState int32
+}
+
+func newFSEEncoder(norm []int16, tableLog uint8) *fseEncoder {
+	tableSize := 1 << tableLog
+	mask := tableSize - 1
+	high := tableSize - 1
+
+	// the symbols of probability "less than 1" take the last cells
+	symbolAt := make([]uint8, tableSize)
+	cumul := make([]int, len(norm)+1)
+	for s, count := range norm {
+		if count == -1 {
+			cumul[s+1] = cumul[s] + 1
+			symbolAt[high] = uint8(s)
+			high--
+		} else {
+			cumul[s+1] = cumul[s] + int(count)
+		}
+	}
+
+	step := tableSize>>1 + tableSize>>3 + 3
+	position := 0
+	for s, count := range norm {
+		for i := 0; i < int(count); i++ {
+			symbolAt[position] = uint8(s)
+			position = (position + step) & mask
+			for position > high {
+				position = (position + step) & mask
+			}
+		}
+	}
+
+	e := &fseEncoder{
+		tableLog:   tableLog,
+		stateTable: make([]uint16, tableSize),
+		symbols:    make([]fseSymbol, len(norm)),
+	}
+	for u := 0; u < tableSize; u++ {
+		s := symbolAt[u]
+		e.stateTable[cumul[s]] = uint16(tableSize + u)
+		cumul[s]++
+	}
+
+	total := 0
+	for s, count := range norm {
+		switch count {
+		case 0:
+			e.symbols[s].deltaNbBits = uint32(tableLog+1)<<16 - uint32(tableSize)
+		case -1, 1:
+			e.symbols[s] = fseSymbol{uint32(tableLog)<<16 - uint32(tableSize), int32(total - 1)}
+			total++
+		default:
+			maxBitsOut := uint32(tableLog) - uint32(bits.Len32(uint32(count-1))-1)
+			minStatePlus := uint32(count) << maxBitsOut
+			e.symbols[s] = fseSymbol{maxBitsOut<<16 - minStatePlus, int32(total - int(count))}
+			total += int(count)
+		}
+	}
+	return e
+}
+
+// init returns the state a stream ending with symbol starts the encoding in
+func (e *fseEncoder) init(symbol uint8) uint32 {
+	tt := e.symbols[symbol]
+	nbBitsOut := (tt.deltaNbBits + 1<<15) >> 16
+	value := nbBitsOut<<16 - tt.deltaNbBits
+	return uint32(e.stateTable[int32(value>>nbBitsOut)+tt.deltaFindState])
+}
+
+// encode writes the bits of the state and moves on to the next symbol
+func (e *fseEncoder) encode(w *zstdBitWriter, state uint32, symbol uint8) uint32 {
+	tt := e.symbols[symbol]
+	nbBitsOut := (state + tt.deltaNbBits) >> 16
+	w.add(uint64(state), uint(nbBitsOut))
+	return uint32(e.stateTable[int32(state>>nbBitsOut)+tt.deltaFindState])
+}
@@ -9,6 +9,7 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/ao-data/albiondata-client/log"
 
@@ -46,6 +47,7 @@ func (w *ansiStripWriter) Write(p []byte) (n int, err error) {
 
 type config struct {
 	AllowedWSHosts                 []string
+	CodeTablePath                  string
 	Debug                          bool
 	Trace                          bool
 	DebugEvents                    map[int]bool
@@ -63,9 +65,15 @@ type config struct {
 	Offline                        bool
 	OfflinePath                    string
 	RecordPath                     string
+	StatePath                      string
 	PrivateIngestBaseUrls          string
 	PublicIngestBaseUrls           string
+	QueuePath                      string
+	UploadMaxAge                   time.Duration
+	Realms                         []realm
 	NoCPULimit                     bool
+	PowWorkers                     int
+	MaxPayloadSize                 int
 	PrintVersion                   bool
 	UpdateGithubOwner              string
 	UpdateGithubRepo               string
@@ -87,10 +95,10 @@ func (config *config) SetupFlags() {
 
 	if config.OfflinePath != "" {
 		config.Offline = true
-		config.DisableUpload = true
-
-		if config.PublicIngestBaseUrls == "http+pow://west.aodp.local:3000" {
-			config.DisableUpload = false
+		// offline runs only upload to targets given explicitly, like a
+		// local mock-ingest
+		if !flagGiven("i") && !flagGiven("p") {
+			config.DisableUpload = true
 		}
 
 		log.Infof("config.PublicIngestBaseUrls: %v", config.PublicIngestBaseUrls)
@@ -104,6 +112,17 @@ func (config *config) SetupFlags() {
 	config.setupLogs()
 }
 
+// flagGiven tells whether a flag was set on the command line
+func flagGiven(name string) bool {
+	given := false
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			given = true
+		}
+	})
+	return given
+}
+
 func (config *config) setupWebsocketFlags() {
 	// Setup the config file and parse values
 	viper.SetConfigName("config")
@@ -125,6 +144,16 @@ func (config *config) setupWebsocketFlags() {
 	if viper.IsSet("UpdateGithubRepo") {
 		config.UpdateGithubRepo = viper.GetString("UpdateGithubRepo")
 	}
+
+	// A code table on disk replaces the embedded one, -codes still wins
+	config.CodeTablePath = viper.GetString("CodeTable")
+
+	// Realms replace the built-in game server ranges
+	if viper.IsSet("Realms") {
+		if err := viper.UnmarshalKey("Realms", &config.Realms); err != nil {
+			log.Errorf("Could not read Realms from config.yaml: %v", err)
+		}
+	}
 }
 
 func (config *config) setupDebugFlags() {
@@ -191,6 +220,20 @@ func (config *config) setupDebugFlags() {
 		"Use all available CPU cores",
 	)
 
+	flag.IntVar(
+		&config.PowWorkers,
+		"pow-workers",
+		0,
+		"How many cores may solve the proof of work of the uploads, 0 for a quarter of them (all with -no-limit)",
+	)
+
+	flag.IntVar(
+		&config.MaxPayloadSize,
+		"max-payload",
+		0,
+		"Largest market upload in bytes, larger ones are split at order boundaries, e.g. 524288 for an ingest limiting its request size. 0 never splits.",
+	)
+
 }
 
 func (config *config) setupCommonFlags() {
@@ -226,14 +269,42 @@ func (config *config) setupCommonFlags() {
 		&config.PublicIngestBaseUrls,
 		"i",
 		"https+pow://albion-online-data.com",
-		"Base URL to send PUBLIC data to, can be 'nats://', 'http://', 'https://' or 'noop' and can have multiple uploaders. Comma separated.",
+		"Base URL to send PUBLIC data to, can be 'nats://', 'http://', 'https://', 'http+pow://', 'https+pow://', 'file://', 'marketdb://', 'mqtt://', 'mqtts://', 'redis://', 'rediss://', 'log' or 'noop' and can have multiple uploaders. Comma separated. Options go in the query, e.g. ?timeout=10s&topics=marketorders.ingest, HTTP targets also take compression=gzip|zstd",
 	)
 
 	flag.StringVar(
 		&config.PrivateIngestBaseUrls,
 		"p",
 		"",
-		"Base URL to send PRIVATE data to, can be 'nats://', 'http://', 'https://' or 'noop' and can have multiple uploaders. Comma separated.",
+		"Base URL to send PRIVATE data to, can be 'nats://', 'http://', 'https://', 'http+pow://', 'https+pow://', 'file://', 'marketdb://', 'mqtt://', 'mqtts://', 'redis://', 'rediss://', 'log' or 'noop' and can have multiple uploaders. Comma separated. Options go in the query, e.g. ?timeout=10s&topics=skills, HTTP targets also take token, secret, header, format=discord|slack and compression=gzip|zstd",
+	)
+
+	flag.StringVar(
+		&config.CodeTablePath,
+		"codes",
+		config.CodeTablePath,
+		"Load the operation and event code table from this file instead of the built-in one.",
+	)
+
+	flag.StringVar(
+		&config.StatePath,
+		"state",
+		dataPath(stateFileName),
+		"Save the player state to this file and restore it after a restart. Empty to disable.",
+	)
+
+	flag.StringVar(
+		&config.QueuePath,
+		"queue",
+		queueDirName,
+		"Keep the uploads in this directory until they are sent, so they survive a restart. Empty to keep them in memory only.",
+	)
+
+	flag.DurationVar(
+		&config.UploadMaxAge,
+		"queue-max-age",
+		defaultUploadMaxAge,
+		"Retry failed uploads for this long before dropping them.",
 	)
 
 	flag.StringVar(
@@ -299,6 +370,17 @@ func rotateLogFiles() {
 	_ = os.Rename(logFileName, fmt.Sprintf("%s.1", logFileName))
 }
 
+// dataPath is where a file the client keeps between runs goes by default:
+// in the user's config directory, so it does not depend on where the client
+// is started from. Without one it falls back to the working directory.
+func dataPath(name string) string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return name
+	}
+	return filepath.Join(dir, "albiondata-client", name)
+}
+
 // GetLogFilePath returns the full path to the current log file
 func GetLogFilePath() string {
 	absPath, err := filepath.Abs(logFileName)
@@ -0,0 +1,126 @@
+package client
+
+import (
+	"sync"
+	"time"
+)
+
+// How long a request waits for its response before it is forgotten
+const correlationTTL = 2 * time.Minute
+
+// correlation is one request waiting for its response
+type correlation[T any] struct {
+	value   T
+	ready   chan struct{}
+	stored  bool
+	expires time.Time
+}
+
+// correlator pairs requests with their responses by the full message number
+// (param 255). The request handler puts what was asked, the response handler
+// takes it and is woken as soon as the request arrives.
+type correlator[T any] struct {
+	mu      sync.Mutex
+	entries map[uint64]*correlation[T]
+	ttl     time.Duration
+	// expired entries are swept once there are this many, and the oldest are
+	// dropped if that is not enough
+	limit int
+}
+
+func newCorrelator[T any](ttl time.Duration, limit int) *correlator[T] {
+	return &correlator[T]{
+		entries: make(map[uint64]*correlation[T]),
+		ttl:     ttl,
+		limit:   limit,
+	}
+}
+
+// entry returns the entry for id, creating it if needed. Must be called with
+// the mutex held.
+func (c *correlator[T]) entry(id uint64, now time.Time) *correlation[T] {
+	e, ok := c.entries[id]
+	if !ok {
+		e = &correlation[T]{ready: make(chan struct{})}
+		c.entries[id] = e
+	}
+	e.expires = now.Add(c.ttl)
+	return e
+}
+
+// expire drops the entries nobody asked for in time. Must be called with the
+// mutex held.
+func (c *correlator[T]) expire(now time.Time) {
+	for id, e := range c.entries {
+		if now.After(e.expires) {
+			delete(c.entries, id)
+		}
+	}
+}
+
+// evictOldest drops the entry closest to expiring. Must be called with the
+// mutex held.
+func (c *correlator[T]) evictOldest() {
+	var oldest uint64
+	var found bool
+	for id, e := range c.entries {
+		if !found || e.expires.Before(c.entries[oldest].expires) {
+			oldest, found = id, true
+		}
+	}
+	if found {
+		delete(c.entries, oldest)
+	}
+}
+
+// put stores the request for id and wakes a response waiting for it
+func (c *correlator[T]) put(id uint64, value T) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	if _, exists := c.entries[id]; !exists && len(c.entries) >= c.limit {
+		c.expire(now)
+		for len(c.entries) >= c.limit {
+			c.evictOldest()
+		}
+	}
+
+	e := c.entry(id, now)
+	if e.stored {
+		return
+	}
+	e.value = value
+	e.stored = true
+	close(e.ready)
+}
+
+// take returns and forgets the request for id. If it has not arrived yet, it
+// waits up to timeout for it.
+func (c *correlator[T]) take(id uint64, timeout time.Duration) (T, bool) {
+	c.mu.Lock()
+	e := c.entry(id, time.Now())
+	stored := e.stored
+	c.mu.Unlock()
+
+	if !stored {
+		timer := time.NewTimer(timeout)
+		select {
+		case <-e.ready:
+		case <-timer.C:
+		}
+		timer.Stop()
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.entries[id] == e {
+		delete(c.entries, id)
+	}
+	if !e.stored {
+		var zero T
+		return zero, false
+	}
+	return e.value, true
+}
@@ -0,0 +1,89 @@
+package client
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCorrelatorTakesWhatWasPut(t *testing.T) {
+	c := newCorrelator[string](time.Minute, 10)
+	c.put(1<<40+7, "asked")
+
+	// the full message number is the key, not a slot
+	if _, ok := c.take(7, 0); ok {
+		t.Error("took the request of another message number")
+	}
+	if value, ok := c.take(1<<40+7, 0); !ok || value != "asked" {
+		t.Errorf("take() = %q, %v, want asked, true", value, ok)
+	}
+	if _, ok := c.take(1<<40+7, 0); ok {
+		t.Error("a request was taken twice")
+	}
+}
+
+func TestCorrelatorWakesWaitingResponse(t *testing.T) {
+	c := newCorrelator[string](time.Minute, 10)
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		c.put(3, "late")
+	}()
+
+	start := time.Now()
+	value, ok := c.take(3, 5*time.Second)
+	if !ok || value != "late" {
+		t.Fatalf("take() = %q, %v, want late, true", value, ok)
+	}
+	if waited := time.Since(start); waited > time.Second {
+		t.Errorf("waited %v, the response was not woken", waited)
+	}
+}
+
+func TestCorrelatorLimit(t *testing.T) {
+	c := newCorrelator[int](time.Minute, 4)
+	for id := uint64(0); id < 10; id++ {
+		c.put(id, int(id))
+		time.Sleep(time.Millisecond)
+	}
+
+	if len(c.entries) > 4 {
+		t.Errorf("%d entries are kept, the limit is 4", len(c.entries))
+	}
+	if _, ok := c.take(0, 0); ok {
+		t.Error("the oldest entry was not evicted")
+	}
+	if value, ok := c.take(9, 0); !ok || value != 9 {
+		t.Errorf("take(9) = %v, %v, want 9, true", value, ok)
+	}
+}
+
+func TestCorrelatorExpiry(t *testing.T) {
+	c := newCorrelator[int](time.Millisecond, 2)
+	c.put(1, 1)
+	c.put(2, 2)
+	time.Sleep(5 * time.Millisecond)
+	c.put(3, 3)
+
+	if _, ok := c.entries[1]; ok {
+		t.Error("an expired entry was kept")
+	}
+	if value, ok := c.take(3, 0); !ok || value != 3 {
+		t.Errorf("take(3) = %v, %v, want 3, true", value, ok)
+	}
+}
+
+func TestAuctionOffersResponseWithoutOrdersTakesRequest(t *testing.T) {
+	debug := ConfigGlobal.Debug
+	ConfigGlobal.Debug = true
+	defer func() { ConfigGlobal.Debug = debug }()
+
+	state := newAlbionState(stateSnapshot{}, nil)
+	defer state.stop()
+
+	state.auctionOffersRequests.put(42, operationAuctionGetOffers{MessageID: 42})
+	operationAuctionGetOffersResponse{MessageID: 42}.Process(state)
+
+	if n := len(state.auctionOffersRequests.entries); n != 0 {
+		t.Errorf("%d requests left after an empty response", n)
+	}
+}
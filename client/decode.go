@@ -2,6 +2,7 @@ package client
 
 import (
 	"encoding/hex"
+	"fmt"
 	"reflect"
 	"strconv"
 
@@ -10,97 +11,49 @@ import (
 	"github.com/mitchellh/mapstructure"
 )
 
-func decodeRequest(params map[uint8]interface{}) (operation operation, err error) {
-	if _, ok := params[253]; !ok {
-		return nil, nil
-	}
+//go:generate go run gen_decoders.go
 
-	code := params[253].(int16)
-
-	switch OperationType(code) {
-	case opGetGameServerByCluster:
-		operation = &operationGetGameServerByCluster{}
-	case opAuctionGetOffers:
-		operation = &operationAuctionGetOffers{}
-	case opAuctionGetItemAverageStats:
-		operation = &operationAuctionGetItemAverageStats{}
-	case opGetClusterMapInfo:
-		operation = &operationGetClusterMapInfo{}
-	// case opGoldMarketGetAverageInfo:
-	case opGoldMarketGetAverageInfo:
-		operation = &operationGoldMarketGetAverageInfo{}
-	case opRealEstateGetAuctionData:
-		operation = &operationRealEstateGetAuctionData{}
-	case opRealEstateBidOnAuction:
-		operation = &operationRealEstateBidOnAuction{}
-	default:
-		return nil, nil
-	}
-
-	err = decodeParams(params, operation)
-
-	return operation, err
+// paramDecoder is implemented by the generated decode methods in
+// decode_generated.go. Structs without one fall back to mapstructure.
+type paramDecoder interface {
+	decode(params map[uint8]interface{}) error
 }
 
-func decodeResponse(params map[uint8]interface{}) (operation operation, err error) {
-	if _, ok := params[253]; !ok {
-		return nil, nil
+// codeParam returns the param key holding the message code
+func codeParam(kind messageKind) uint8 {
+	if kind == kindEvent {
+		return 252
 	}
-
-	code := params[253].(int16)
-
-	switch OperationType(code) {
-	case opJoin:
-		operation = &operationJoinResponse{}
-	case opAuctionGetOffers:
-		operation = &operationAuctionGetOffersResponse{}
-	case opAuctionGetRequests:
-		operation = &operationAuctionGetRequestsResponse{}
-	case opAuctionBuyOffer:
-		operation = &operationAuctionGetRequestsResponse{}
-	case opAuctionGetItemAverageStats:
-		operation = &operationAuctionGetItemAverageStatsResponse{}
-	case opGetMailInfos:
-		operation = &operationGetMailInfosResponse{}
-	case opReadMail:
-		operation = &operationReadMail{}
-	case opGetClusterMapInfo:
-		operation = &operationGetClusterMapInfoResponse{}
-	// case opGoldMarketGetAverageInfo:
-	case opGoldMarketGetAverageInfo:
-		operation = &operationGoldMarketGetAverageInfoResponse{}
-	case opRealEstateGetAuctionData:
-		operation = &operationRealEstateGetAuctionDataResponse{}
-	case opRealEstateBidOnAuction:
-		operation = &operationRealEstateBidOnAuctionResponse{}
-	default:
-		return nil, nil
-	}
-
-	err = decodeParams(params, operation)
-
-	return operation, err
+	return 253
 }
 
-func decodeEvent(params map[uint8]interface{}) (event operation, err error) {
-	if _, ok := params[252]; !ok {
+// decodeMessage decodes the params into every handler registered for the
+// message code. Messages without handlers decode to nothing. Every message is
+// also shown to the drift detector.
+func decodeMessage(kind messageKind, params map[uint8]interface{}) (operations []operation, err error) {
+	code, ok := params[codeParam(kind)].(int16)
+	if !ok {
 		return nil, nil
 	}
 
-	eventType := params[252].(int16)
-
-	switch eventType {
-	// case evRespawn: //TODO: confirm this eventCode (old 77)
-	// 	event = &eventPlayerOnlineStatus{}
-	// case evCharacterStats: //TODO: confirm this eventCode (old 114)
-	// 	event = &eventSkillData{}
-	default:
-		return nil, nil
+	if drift != nil {
+		drift.observe(kind, params)
 	}
 
-	err = decodeParams(params, event)
+	for _, factory := range handlers.lookup(kind, code) {
+		operation := factory()
+		if decoder, ok := operation.(paramDecoder); ok {
+			err = decoder.decode(params)
+		} else {
+			err = decodeParams(params, operation)
+		}
+		if err != nil {
+			return nil, err
+		}
+		operations = append(operations, operation)
+	}
 
-	return event, err
+	return operations, nil
 }
 
 func decodeParams(params map[uint8]interface{}, operation operation) error {
@@ -108,7 +61,7 @@ func decodeParams(params map[uint8]interface{}, operation operation) error {
 		if from == reflect.TypeOf([]int8{}) && to == reflect.TypeOf(lib.CharacterID("")) {
 			log.Debug("Parsing character ID from mixed-endian UUID")
 
-			return decodeCharacterID(v.([]int8)), nil
+			return decodeCharacterIDParam(v)
 		}
 
 		return v, nil
@@ -137,6 +90,155 @@ func decodeParams(params map[uint8]interface{}, operation operation) error {
 	return err
 }
 
+// The helpers below are used by the generated decoders. Photon sends numbers in
+// whatever width fits the value, so any number converts to any number type.
+
+type number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 |
+		~float32 | ~float64
+}
+
+func paramError(key uint8, field string, err error) error {
+	return fmt.Errorf("param %d (%v): %v", key, field, err)
+}
+
+func decodeBool(v interface{}) (bool, error) {
+	b, ok := v.(bool)
+	if !ok {
+		return false, fmt.Errorf("expected a bool, got %T", v)
+	}
+	return b, nil
+}
+
+func decodeString(v interface{}) (string, error) {
+	s, ok := v.(string)
+	if !ok {
+		return "", fmt.Errorf("expected a string, got %T", v)
+	}
+	return s, nil
+}
+
+func decodeCharacterIDParam(v interface{}) (lib.CharacterID, error) {
+	switch id := v.(type) {
+	case []int8:
+		// a UUID, anything else would not fill it or be cut
+		if len(id) != 16 {
+			return "", fmt.Errorf("expected a character ID of 16 bytes, got %d", len(id))
+		}
+		return decodeCharacterID(id), nil
+	case string:
+		return lib.CharacterID(id), nil
+	}
+	return "", fmt.Errorf("expected a character ID, got %T", v)
+}
+
+func decodeNumber[T number](v interface{}) (T, error) {
+	switch n := v.(type) {
+	case int8:
+		return T(n), nil
+	case int16:
+		return T(n), nil
+	case int32:
+		return T(n), nil
+	case int64:
+		return T(n), nil
+	case uint8:
+		return T(n), nil
+	case uint16:
+		return T(n), nil
+	case uint32:
+		return T(n), nil
+	case uint64:
+		return T(n), nil
+	case int:
+		return T(n), nil
+	case float32:
+		return T(n), nil
+	case float64:
+		return T(n), nil
+	}
+	return 0, fmt.Errorf("expected a number, got %T", v)
+}
+
+func convertNumbers[T number, S number](from []S) []T {
+	to := make([]T, len(from))
+	for i, n := range from {
+		to[i] = T(n)
+	}
+	return to
+}
+
+func decodeNumbers[T number](v interface{}) ([]T, error) {
+	switch n := v.(type) {
+	case []int8:
+		return convertNumbers[T](n), nil
+	case []int16:
+		return convertNumbers[T](n), nil
+	case []int32:
+		return convertNumbers[T](n), nil
+	case []int64:
+		return convertNumbers[T](n), nil
+	case []uint8:
+		return convertNumbers[T](n), nil
+	case []uint16:
+		return convertNumbers[T](n), nil
+	case []uint32:
+		return convertNumbers[T](n), nil
+	case []uint64:
+		return convertNumbers[T](n), nil
+	case []int:
+		return convertNumbers[T](n), nil
+	case []float32:
+		return convertNumbers[T](n), nil
+	case []float64:
+		return convertNumbers[T](n), nil
+	case []interface{}:
+		to := make([]T, len(n))
+		for i := range n {
+			var err error
+			if to[i], err = decodeNumber[T](n[i]); err != nil {
+				return nil, fmt.Errorf("element %d: %v", i, err)
+			}
+		}
+		return to, nil
+	}
+	return nil, fmt.Errorf("expected a number array, got %T", v)
+}
+
+func decodeNumberSlices[T number](v interface{}) ([][]T, error) {
+	slices, ok := v.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("expected an array of number arrays, got %T", v)
+	}
+
+	to := make([][]T, len(slices))
+	for i := range slices {
+		var err error
+		if to[i], err = decodeNumbers[T](slices[i]); err != nil {
+			return nil, fmt.Errorf("element %d: %v", i, err)
+		}
+	}
+	return to, nil
+}
+
+func decodeStrings(v interface{}) ([]string, error) {
+	switch s := v.(type) {
+	case []string:
+		return s, nil
+	case []interface{}:
+		to := make([]string, len(s))
+		for i := range s {
+			var err error
+			if to[i], err = decodeString(s[i]); err != nil {
+				return nil, fmt.Errorf("element %d: %v", i, err)
+			}
+		}
+		return to, nil
+	}
+	return nil, fmt.Errorf("expected a string array, got %T", v)
+}
+
 func decodeCharacterID(array []int8) lib.CharacterID {
 	/* So this is a UUID, which is stored in a 'mixed-endian' format.
 	The first three components are stored in little-endian, the rest in big-endian.
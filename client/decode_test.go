@@ -0,0 +1,215 @@
+package client
+
+import (
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/ao-data/albiondata-client/lib"
+)
+
+// benchmarkFixtures are the messages seen most while browsing the market
+var benchmarkFixtures = []string{
+	"operations/AuctionGetOffers.request.json",
+	"operations/AuctionGetOffers.response.json",
+	"operations/AuctionGetItemAverageStats.response.json",
+	"operations/GetMailInfos.response.json",
+}
+
+// decodeBoth decodes params with the generated decoder and with mapstructure
+// into fresh handlers of the same type
+func decodeBoth(t testing.TB, factory handlerFactory, params map[uint8]interface{}) (generated, reflected operation) {
+	t.Helper()
+
+	generated, reflected = factory(), factory()
+	decoder, ok := generated.(paramDecoder)
+	if !ok {
+		t.Fatalf("%T has no generated decoder, run go generate", generated)
+	}
+	if err := decoder.decode(params); err != nil {
+		t.Fatalf("%T: generated: %v", generated, err)
+	}
+	if err := decodeParams(params, reflected); err != nil {
+		t.Fatalf("%T: mapstructure: %v", reflected, err)
+	}
+	return generated, reflected
+}
+
+func TestGeneratedDecodersMatchFixtures(t *testing.T) {
+	paths, err := filepath.Glob("testdata/*/*.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, path := range paths {
+		path, _ = filepath.Rel("testdata", path)
+		kind, params := loadFixture(t, path)
+		factories := handlers.lookup(kind, params[codeParam(kind)].(int16))
+		if len(factories) == 0 {
+			t.Errorf("%v: no handler", path)
+		}
+		for _, factory := range factories {
+			if remainsParams(factory()) {
+				continue
+			}
+			generated, reflected := decodeBoth(t, factory, params)
+			if !reflect.DeepEqual(generated, reflected) {
+				t.Errorf("%v: the decoders disagree\ngenerated:   %+v\nmapstructure: %+v", path, generated, reflected)
+			}
+		}
+	}
+}
+
+// TestGeneratedDecodersMatchTags fills every tagged field of every handler,
+// so a struct changed without go generate shows up even without a fixture
+func TestGeneratedDecodersMatchTags(t *testing.T) {
+	for _, kind := range []messageKind{kindRequest, kindResponse, kindEvent} {
+		for _, name := range handlers.names(kind) {
+			for _, factory := range handlers.handlers[kind][name] {
+				if remainsParams(factory()) {
+					continue
+				}
+				params := sampleParams(t, factory())
+				generated, reflected := decodeBoth(t, factory, params)
+				if !reflect.DeepEqual(generated, reflected) {
+					t.Errorf("%v %v: the decoders disagree\ngenerated:   %+v\nmapstructure: %+v", kind, name, generated, reflected)
+				}
+				if reflect.DeepEqual(generated, factory()) && len(params) > 0 {
+					t.Errorf("%v %v: nothing was decoded from %v", kind, name, params)
+				}
+			}
+		}
+	}
+}
+
+// remainsParams tells whether a handler takes all params through a
+// mapstructure:",remain" field. mapstructure cannot fill those, their uint8
+// keys were turned into strings, so only the generated decoder handles them.
+func remainsParams(handler operation) bool {
+	typ := reflect.TypeOf(handler).Elem()
+	for i := 0; i < typ.NumField(); i++ {
+		if typ.Field(i).Tag.Get("mapstructure") == ",remain" {
+			return true
+		}
+	}
+	return false
+}
+
+func TestGeneratedDecoderRemain(t *testing.T) {
+	kind, params := loadFixture(t, "operations/GetGameServerByCluster.response.json")
+	operations, err := decodeMessage(kind, params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(operations) != 1 {
+		t.Fatalf("got %d operations, want 1", len(operations))
+	}
+	op, ok := operations[0].(*operationGetGameServerByClusterResponse)
+	if !ok {
+		t.Fatalf("got %T", operations[0])
+	}
+	if !reflect.DeepEqual(op.Params, params) {
+		t.Errorf("Params = %v, want %v", op.Params, params)
+	}
+}
+
+// sampleParams builds params for the tagged fields of a handler, in the
+// types photon decodes them to
+func sampleParams(t *testing.T, handler operation) map[uint8]interface{} {
+	params := make(map[uint8]interface{})
+	typ := reflect.TypeOf(handler).Elem()
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		tag := field.Tag.Get("mapstructure")
+		if strings.HasPrefix(tag, ",") {
+			continue
+		}
+		key, err := strconv.Atoi(tag)
+		if err != nil {
+			continue
+		}
+		params[uint8(key)] = sampleValue(t, field.Type, key)
+	}
+	return params
+}
+
+func sampleValue(t *testing.T, typ reflect.Type, key int) interface{} {
+	if typ == reflect.TypeOf(lib.CharacterID("")) {
+		return []int8{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, int8(key)}
+	}
+	switch typ.Kind() {
+	case reflect.Bool:
+		return true
+	case reflect.String:
+		return "value " + strconv.Itoa(key)
+	case reflect.Int8, reflect.Uint8:
+		return int8(key % 100)
+	case reflect.Int, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return int32(1000 + key)
+	case reflect.Float32, reflect.Float64:
+		return float32(key) + 0.5
+	case reflect.Slice:
+		switch typ.Elem().Kind() {
+		case reflect.String:
+			return []string{"first " + strconv.Itoa(key), "second"}
+		case reflect.Float32, reflect.Float64:
+			return []float32{0.25, float32(key)}
+		case reflect.Int8, reflect.Uint8:
+			return []int8{1, int8(key % 100)}
+		case reflect.Slice:
+			// an array of arrays comes as an object array
+			return []interface{}{[]int16{1, 2}, []int16{int16(key)}}
+		default:
+			return []int64{639000000000000000, int64(key)}
+		}
+	}
+	t.Fatalf("no sample value for %v", typ)
+	return nil
+}
+
+func benchmarkDecode(b *testing.B, decode func(handlerFactory, map[uint8]interface{}) error) {
+	for _, path := range benchmarkFixtures {
+		kind, params := loadFixture(b, path)
+		factory := handlers.lookup(kind, params[codeParam(kind)].(int16))[0]
+		b.Run(strings.TrimSuffix(filepath.Base(path), ".json"), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if err := decode(factory, params); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkDecodeParams(b *testing.B) {
+	benchmarkDecode(b, func(factory handlerFactory, params map[uint8]interface{}) error {
+		return decodeParams(params, factory())
+	})
+}
+
+func BenchmarkDecodeGenerated(b *testing.B) {
+	benchmarkDecode(b, func(factory handlerFactory, params map[uint8]interface{}) error {
+		return factory().(paramDecoder).decode(params)
+	})
+}
+
+func TestDecodeCharacterIDLength(t *testing.T) {
+	id := []int8{0x67, 0x45, 0x23, 0x01, -0x55, -0x77, -0x11, -0x33, 0x01, 0x23, 0x45, 0x67, -0x77, -0x55, -0x33, -0x11}
+	for _, array := range [][]int8{{}, id[:15], append(append([]int8{}, id...), 0)} {
+		params := map[uint8]interface{}{1: array}
+		if err := (&operationJoinResponse{}).decode(params); err == nil {
+			t.Errorf("generated: a character ID of %d bytes was taken", len(array))
+		}
+		if err := decodeParams(params, &operationJoinResponse{}); err == nil {
+			t.Errorf("mapstructure: a character ID of %d bytes was taken", len(array))
+		}
+	}
+
+	want := lib.CharacterID("01234567-89ab-cdef-0123-456789abcdef")
+	if got, err := decodeCharacterIDParam(id); err != nil || got != want {
+		t.Errorf("got %v (%v), want %v", got, err, want)
+	}
+}
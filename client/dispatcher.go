@@ -4,13 +4,19 @@ import (
 	"encoding/json"
 	"net/http"
 
-	"strings"
+	"time"
 
 	"github.com/ao-data/albiondata-client/lib"
 	"github.com/ao-data/albiondata-client/log"
 )
 
-type dispatcher struct{}
+type dispatcher struct {
+	// Slow handlers run here so they do not hold up the ordered processing
+	workers *workerPool
+
+	// The uploaders of the configured targets, each behind a queue
+	uploads *uploaderPool
+}
 
 var (
 	wsHub *WSHub
@@ -18,7 +24,15 @@ var (
 )
 
 func createDispatcher() {
-	dis = &dispatcher{}
+	dis = &dispatcher{
+		workers: newWorkerPool(workerCount, workerQueueSize),
+	}
+
+	if ConfigGlobal.DisableUpload {
+		dis.uploads = newUploaderPool("", "", "", ConfigGlobal.UploadMaxAge)
+	} else {
+		dis.uploads = newUploaderPool(ConfigGlobal.PublicIngestBaseUrls, ConfigGlobal.PrivateIngestBaseUrls, ConfigGlobal.QueuePath, ConfigGlobal.UploadMaxAge)
+	}
 
 	if ConfigGlobal.EnableWebsockets {
 		wsHub = newHub()
@@ -27,54 +41,34 @@ func createDispatcher() {
 	}
 }
 
-func createUploaders(targets []string) []uploader {
-	var uploaders []uploader
-	for _, target := range targets {
-		if target == "" {
-			continue
-		}
-		if len(target) < 4 {
-			log.Infof("Got an ingest target that was less than 4 characters, not a valid ingest target: %v", target)
-			continue
-		}
-
-		if target[0:8] == "http+pow" ||  target[0:9] == "https+pow" {
-			uploaders = append(uploaders, newHTTPUploaderPow(target))
-		} else if target[0:4] == "http" || target[0:5] == "https" {
-			uploaders = append(uploaders, newHTTPUploader(target))
-		} else if target[0:4] == "nats" {
-			uploaders = append(uploaders, newNATSUploader(target))
-		} else {
-			log.Infof("An invalid ingest target was specified: %v", target)
-		}
+// closeDispatcher runs the queued slow handlers, then stops the upload
+// queues, giving the queued uploads up to timeout to go out. The router must
+// be stopped first, the handlers are what fills the queues.
+func closeDispatcher(timeout time.Duration) {
+	if dis != nil {
+		dis.workers.close()
+		dis.uploads.close(timeout)
 	}
-
-	return uploaders
 }
 
 func sendMsgToPublicUploaders(upload interface{}, topic string, state *albionState, identifier string) {
+	snapshot := state.snapshot()
+
 	data, err := json.Marshal(upload)
 	if err != nil {
 		log.Errorf("Error while marshalling payload for %v: %v", err, topic)
 		return
 	}
 
-	var PublicIngestBaseUrls = ConfigGlobal.PublicIngestBaseUrls
-	// http+pow://albion-online-data.com is used as a magic placeholder for every realm there is
-	if strings.Contains(ConfigGlobal.PublicIngestBaseUrls, "https+pow://albion-online-data.com") {
-		// we replace the placeholder with the correct one based on the serverID from albionState
-		PublicIngestBaseUrls = strings.Replace(PublicIngestBaseUrls, "https+pow://albion-online-data.com", state.AODataIngestBaseURL, -1)
+	// the placeholder target is replaced by the ingest of the player's realm
+	for _, part := range splitUpload(upload, data, identifier, ConfigGlobal.MaxPayloadSize) {
+		sendMsgToUploaders(part.data, topic, dis.uploads.publicQueues(snapshot), snapshot, part.identifier)
+		sendMsgToUploaders(part.data, topic, dis.uploads.privateQueues(), snapshot, part.identifier)
 	}
 
-	var publicUploaders = createUploaders(strings.Split(PublicIngestBaseUrls, ","))
-	var privateUploaders = createUploaders(strings.Split(ConfigGlobal.PrivateIngestBaseUrls, ","))
-
-	sendMsgToUploaders(data, topic, publicUploaders, state, identifier)
-	sendMsgToUploaders(data, topic, privateUploaders, state, identifier)
-
-	// If websockets are enabled, send the data there too
+	// If websockets are enabled, send the data there too, whole
 	if ConfigGlobal.EnableWebsockets {
-		sendMsgToWebSockets(data, topic)
+		sendMsgToWebSockets(data, topic, snapshot.Location)
 	}
 }
 
@@ -92,7 +86,8 @@ func sendMsgToPrivateUploaders(upload lib.PersonalizedUpload, topic string, stat
 	// 	return
 	// }
 
-	upload.Personalize(state.CharacterId, state.CharacterName)
+	snapshot := state.snapshot()
+	upload.Personalize(snapshot.CharacterId, snapshot.CharacterName)
 
 	data, err := json.Marshal(upload)
 	if err != nil {
@@ -100,25 +95,24 @@ func sendMsgToPrivateUploaders(upload lib.PersonalizedUpload, topic string, stat
 		return
 	}
 
-	var privateUploaders = createUploaders(strings.Split(ConfigGlobal.PrivateIngestBaseUrls, ","))
-	if len(privateUploaders) > 0 {
-		sendMsgToUploaders(data, topic, privateUploaders, state, identifier)
-	}
+	sendMsgToUploaders(data, topic, dis.uploads.privateQueues(), snapshot, identifier)
 
 	// If websockets are enabled, send the data there too
 	if ConfigGlobal.EnableWebsockets {
-		sendMsgToWebSockets(data, topic)
+		sendMsgToWebSockets(data, topic, snapshot.Location)
 	}
 }
 
-func sendMsgToUploaders(msg []byte, topic string, uploaders []uploader, state *albionState, identifier string) {
+// sendMsgToUploaders queues the payload for every target, the queues send it
+// in the background
+func sendMsgToUploaders(msg []byte, topic string, queues []*uploadQueue, state stateSnapshot, identifier string) {
 	if ConfigGlobal.DisableUpload {
 		log.Info("Upload is disabled.")
 		return
 	}
 
-	for _, u := range uploaders {
-		u.sendToIngest(msg, topic, state, identifier)
+	for _, q := range queues {
+		q.push(msg, topic, state, identifier)
 	}
 }
 
@@ -134,10 +128,17 @@ func runHTTPServer() {
 	}
 }
 
-func sendMsgToWebSockets(msg []byte, topic string) {
-	// TODO (gradius): send JSON data with topic string
-	// TODO (gradius): this seems super hacky, and I'm sure there's a better way.
-	var result string
-	result = "{\"topic\": \"" + topic + "\", \"data\": " + string(msg) + "}"
-	wsHub.broadcast <- []byte(result)
+// sendMsgToWebSockets wraps the upload with its topic and where the player
+// was, with the location names resolved
+func sendMsgToWebSockets(msg []byte, topic string, location lib.Location) {
+	result, err := json.Marshal(struct {
+		Topic    string          `json:"topic"`
+		Data     json.RawMessage `json:"data"`
+		Location lib.Location    `json:"location"`
+	}{topic, msg, location})
+	if err != nil {
+		log.Errorf("Error while marshalling websocket message for %v: %v", topic, err)
+		return
+	}
+	wsHub.broadcast <- result
 }
@@ -0,0 +1,522 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/ao-data/albiondata-client/lib"
+	"github.com/ao-data/albiondata-client/log"
+)
+
+const (
+	// driftHits is how often a known shape has to show up under another code
+	// before we believe the code moved
+	driftHits = 3
+
+	// driftMinParams is how many params a fingerprint without JSON needs to be
+	// told apart from other messages, one of them an array
+	driftMinParams = 3
+
+	// driftProposalFile receives the remapped code table once drift is found
+	driftProposalFile = "codes.proposed.json"
+)
+
+// paramKind is a coarse type of a photon parameter. Photon sends numbers and
+// number arrays in whatever width fits, so widths are not part of the shape.
+type paramKind string
+
+const (
+	paramBool        paramKind = "bool"
+	paramNumber      paramKind = "number"
+	paramString      paramKind = "string"
+	paramJSON        paramKind = "json"
+	paramNumberSlice paramKind = "[]number"
+	paramStringSlice paramKind = "[]string"
+	paramJSONSlice   paramKind = "[]json"
+	paramSlice       paramKind = "[]any"
+	paramOther       paramKind = "unknown"
+)
+
+// fits tells whether an observed param kind satisfies the expected one
+func (expected paramKind) fits(observed paramKind) bool {
+	switch {
+	case expected == observed:
+		return true
+	case expected == paramString && observed == paramJSON:
+		return true
+	case expected == paramStringSlice && observed == paramJSONSlice:
+		return true
+	case expected == paramSlice && strings.HasPrefix(string(observed), "[]"):
+		return true
+	}
+	return false
+}
+
+// fingerprint is the parameter shape a handler expects for its message
+type fingerprint struct {
+	kind   messageKind
+	name   string
+	params map[uint8]paramKind
+}
+
+// strong fingerprints are specific enough to recognize a message by. JSON
+// documents are, a few numbers or strings are shared by many messages.
+func (fp *fingerprint) strong() bool {
+	var slice bool
+	for _, kind := range fp.params {
+		switch kind {
+		case paramJSON, paramJSONSlice:
+			return true
+		case paramNumberSlice, paramStringSlice, paramSlice:
+			slice = true
+		}
+	}
+	return slice && len(fp.params) >= driftMinParams
+}
+
+func (fp *fingerprint) matches(params map[uint8]interface{}) bool {
+	for key, expected := range fp.params {
+		value, ok := params[key]
+		if !ok || !expected.fits(observedKind(value)) {
+			return false
+		}
+	}
+	return true
+}
+
+// extraParams counts the params of a message the fingerprint knows nothing
+// about, the code params left out
+func (fp *fingerprint) extraParams(params map[uint8]interface{}) int {
+	extra := 0
+	for key := range params {
+		if _, known := fp.params[key]; !known && key < 252 {
+			extra++
+		}
+	}
+	return extra
+}
+
+func (fp *fingerprint) String() string {
+	var keys []int
+	for key := range fp.params {
+		keys = append(keys, int(key))
+	}
+	sort.Ints(keys)
+
+	var parts []string
+	for _, key := range keys {
+		parts = append(parts, fmt.Sprintf("%d:%v", key, fp.params[uint8(key)]))
+	}
+	return fmt.Sprintf("%v %v {%v}", fp.kind, fp.name, strings.Join(parts, " "))
+}
+
+// newFingerprint derives the expected shape from the mapstructure tags of a
+// handler struct. Fields tagged with fingerprint:"json" carry JSON documents.
+func newFingerprint(kind messageKind, name string, handler operation) *fingerprint {
+	fp := &fingerprint{kind: kind, name: name, params: make(map[uint8]paramKind)}
+
+	t := reflect.TypeOf(handler)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return fp
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		key, err := strconv.Atoi(field.Tag.Get("mapstructure"))
+		// 255 is the message number, every message has it
+		if err != nil || key < 0 || key >= 252 {
+			continue
+		}
+
+		kind := expectedKind(field.Type)
+		if field.Tag.Get("fingerprint") == "json" {
+			switch kind {
+			case paramString:
+				kind = paramJSON
+			case paramStringSlice:
+				kind = paramJSONSlice
+			}
+		}
+		fp.params[uint8(key)] = kind
+	}
+
+	return fp
+}
+
+func expectedKind(t reflect.Type) paramKind {
+	// Character IDs come over the wire as byte arrays
+	if t == reflect.TypeOf(lib.CharacterID("")) {
+		return paramNumberSlice
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return paramBool
+	case reflect.String:
+		return paramString
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return paramNumber
+	case reflect.Slice, reflect.Array:
+		switch expectedKind(t.Elem()) {
+		case paramNumber:
+			return paramNumberSlice
+		case paramString:
+			return paramStringSlice
+		}
+		return paramSlice
+	}
+	return paramOther
+}
+
+func observedKind(value interface{}) paramKind {
+	switch v := value.(type) {
+	case bool:
+		return paramBool
+	case int8, int16, int32, int64, uint8, uint16, uint32, uint64, float32, float64:
+		return paramNumber
+	case string:
+		if looksLikeJSON(v) {
+			return paramJSON
+		}
+		return paramString
+	case []string:
+		if len(v) > 0 && looksLikeJSON(v[0]) {
+			return paramJSONSlice
+		}
+		return paramStringSlice
+	case []int8, []int16, []int32, []int64, []uint8, []uint16, []uint32, []uint64, []float32, []float64:
+		return paramNumberSlice
+	}
+
+	if reflect.TypeOf(value) != nil && reflect.TypeOf(value).Kind() == reflect.Slice {
+		return paramSlice
+	}
+	return paramOther
+}
+
+func looksLikeJSON(s string) bool {
+	s = strings.TrimSpace(s)
+	if s == "" || (s[0] != '{' && s[0] != '[') {
+		return false
+	}
+	return json.Valid([]byte(s))
+}
+
+// driftDetector watches the traffic for known message shapes showing up under
+// other codes than the code table says, which is what a game patch that
+// shifts the codes looks like
+type driftDetector struct {
+	fingerprints []*fingerprint
+
+	mu        sync.Mutex
+	confirmed map[*fingerprint]bool
+	sightings map[*fingerprint]map[int16]int
+	moved     map[*fingerprint]int16
+	// how often each offset was voted for, per kind
+	offsets map[messageKind]map[int]int
+}
+
+func newDriftDetector(registry *handlerRegistry) *driftDetector {
+	d := &driftDetector{
+		confirmed: make(map[*fingerprint]bool),
+		sightings: make(map[*fingerprint]map[int16]int),
+		moved:     make(map[*fingerprint]int16),
+		offsets:   make(map[messageKind]map[int]int),
+	}
+
+	for _, kind := range []messageKind{kindRequest, kindResponse, kindEvent} {
+		for _, name := range registry.names(kind) {
+			for _, factory := range registry.handlers[kind][name] {
+				fp := newFingerprint(kind, name, factory())
+				if !fp.strong() {
+					continue
+				}
+				d.fingerprints = append(d.fingerprints, fp)
+				log.Tracef("Fingerprint %v", fp)
+			}
+		}
+	}
+
+	return d
+}
+
+// drift watches every decoded message
+var drift *driftDetector
+
+func (d *driftDetector) expectedCode(fp *fingerprint) (int16, bool) {
+	if fp.kind == kindEvent {
+		code, ok := codes.event(fp.name)
+		return int16(code), ok
+	}
+	code, ok := codes.operation(fp.name)
+	return int16(code), ok
+}
+
+// observe checks a message against the fingerprints of its kind
+func (d *driftDetector) observe(kind messageKind, params map[uint8]interface{}) {
+	code, ok := params[codeParam(kind)].(int16)
+	if !ok {
+		return
+	}
+
+	var candidates []*fingerprint
+	for _, fp := range d.fingerprints {
+		if fp.kind != kind || !fp.matches(params) {
+			continue
+		}
+		// a message with more params than the handler knows is something
+		// else that happens to carry the same ones
+		if fp.extraParams(params) > len(fp.params) {
+			continue
+		}
+
+		expected, ok := d.expectedCode(fp)
+		if ok && expected == code {
+			// The current code table explains this message
+			d.mu.Lock()
+			d.confirmed[fp] = true
+			d.mu.Unlock()
+			return
+		}
+		candidates = append(candidates, fp)
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	// handlers seen at their code or already moved are not looked for, nor
+	// ones that would have swapped places with them
+	remaining := candidates[:0]
+	for _, fp := range candidates {
+		if _, done := d.moved[fp]; !done && !d.confirmed[fp] && d.keepsOrder(fp, code) {
+			remaining = append(remaining, fp)
+		}
+	}
+	best := bestCandidates(remaining, params)
+	// a patch moves most codes by the same offset, every candidate votes for
+	// the one it would have moved by
+	for _, fp := range best {
+		if offset, ok := d.offset(fp, code); ok {
+			if d.offsets[kind] == nil {
+				d.offsets[kind] = make(map[int]int)
+			}
+			d.offsets[kind][offset]++
+		}
+	}
+	fp := d.likeliest(best, code)
+	if fp == nil {
+		return
+	}
+
+	if d.sightings[fp] == nil {
+		d.sightings[fp] = make(map[int16]int)
+	}
+	d.sightings[fp][code]++
+	if d.sightings[fp][code] < driftHits {
+		return
+	}
+
+	d.moved[fp] = code
+	expected, _ := d.expectedCode(fp)
+	log.Warnf("The %v %v looks like it moved from code %d to %d. The game was probably patched and the client needs an updated code table.", fp.kind, fp.name, expected, code)
+	d.writeProposal()
+}
+
+// offset is how far a fingerprint moved if the message at code is its own
+func (d *driftDetector) offset(fp *fingerprint, code int16) (int, bool) {
+	expected, ok := d.expectedCode(fp)
+	return int(code) - int(expected), ok
+}
+
+// keepsOrder tells whether fp being at code keeps it on the same side of
+// the handlers already placed, a patch inserts and removes codes but does
+// not reorder them. Must be called with the mutex held.
+func (d *driftDetector) keepsOrder(fp *fingerprint, code int16) bool {
+	expected, ok := d.expectedCode(fp)
+	if !ok {
+		return true
+	}
+
+	placed := func(other *fingerprint, at int16) bool {
+		if other.kind != fp.kind || other.name == fp.name {
+			return true
+		}
+		otherExpected, ok := d.expectedCode(other)
+		return !ok || (expected < otherExpected) == (code < at)
+	}
+	for other := range d.confirmed {
+		if otherExpected, ok := d.expectedCode(other); ok && !placed(other, otherExpected) {
+			return false
+		}
+	}
+	for other, at := range d.moved {
+		if !placed(other, at) {
+			return false
+		}
+	}
+	return true
+}
+
+// likeliest picks the candidate whose offset the traffic so far backs the
+// most. Nil when there is none or two are backed equally. Must be called
+// with the mutex held.
+func (d *driftDetector) likeliest(candidates []*fingerprint, code int16) *fingerprint {
+	if len(candidates) == 1 {
+		return candidates[0]
+	}
+
+	var best *fingerprint
+	var bestVotes int
+	var tied bool
+	for _, fp := range candidates {
+		offset, ok := d.offset(fp, code)
+		if !ok {
+			continue
+		}
+		switch votes := d.offsets[fp.kind][offset]; {
+		case best == nil || votes > bestVotes:
+			best, bestVotes, tied = fp, votes, false
+		case votes == bestVotes:
+			tied = true
+		}
+	}
+	if tied {
+		return nil
+	}
+	return best
+}
+
+// bestCandidates returns the fingerprints that explain a message best: the
+// ones with the most params, then the fewest params left unexplained
+func bestCandidates(candidates []*fingerprint, params map[uint8]interface{}) []*fingerprint {
+	var best []*fingerprint
+	var bestParams, bestExtra int
+	for _, fp := range candidates {
+		extra := fp.extraParams(params)
+		switch {
+		case best == nil || len(fp.params) > bestParams || (len(fp.params) == bestParams && extra < bestExtra):
+			best, bestParams, bestExtra = []*fingerprint{fp}, len(fp.params), extra
+		case len(fp.params) == bestParams && extra == bestExtra:
+			best = append(best, fp)
+		}
+	}
+	return best
+}
+
+// proposal returns a copy of the current code table with the names moved by
+// the offsets observed. Every moved or confirmed handler is an anchor, the
+// names between two anchors take the offset of the nearer one, as where
+// exactly the codes moved between them is not known.
+func (d *driftDetector) proposal() *codeTable {
+	anchors := map[messageKind]map[int]int{kindRequest: {}, kindResponse: {}, kindEvent: {}}
+	for fp := range d.confirmed {
+		if expected, ok := d.expectedCode(fp); ok {
+			anchors[fp.kind][int(expected)] = 0
+		}
+	}
+	for fp, code := range d.moved {
+		if offset, ok := d.offset(fp, code); ok {
+			expected, _ := d.expectedCode(fp)
+			anchors[fp.kind][int(expected)] = offset
+		}
+	}
+
+	// requests and responses share the operation codes
+	operations := anchors[kindRequest]
+	for code, offset := range anchors[kindResponse] {
+		operations[code] = offset
+	}
+
+	return &codeTable{
+		Version:    codes.Version + "-proposed",
+		Operations: shiftNames(codes.Operations, operations),
+		Events:     shiftNames(codes.Events, anchors[kindEvent]),
+	}
+}
+
+// shiftNames moves every name by the offset of its nearest anchor. Where two
+// names land on the same code the one nearer its anchor keeps it. Codes no
+// name lands on are filled with placeholders.
+func shiftNames(names []string, anchors map[int]int) []string {
+	var points []int
+	for code := range anchors {
+		points = append(points, code)
+	}
+	sort.Ints(points)
+
+	type placed struct {
+		name     string
+		distance int
+	}
+	var shifted []placed
+	for code, name := range names {
+		offset, distance := 0, -1
+		for _, point := range points {
+			if d := abs(code - point); distance < 0 || d < distance {
+				offset, distance = anchors[point], d
+			}
+		}
+
+		to := code + offset
+		if to < 0 {
+			log.Warnf("%v would move to code %d, leaving it out of the proposed code table", name, to)
+			continue
+		}
+		for len(shifted) <= to {
+			shifted = append(shifted, placed{distance: -1})
+		}
+		if current := shifted[to]; current.name != "" {
+			left := name
+			if distance < current.distance {
+				left = current.name
+				shifted[to] = placed{name, distance}
+			}
+			log.Warnf("%v and %v would both move to code %d, leaving %v out of the proposed code table", current.name, name, to, left)
+			continue
+		}
+		shifted[to] = placed{name, distance}
+	}
+
+	result := make([]string, len(shifted))
+	for code, p := range shifted {
+		result[code] = p.name
+		if p.name == "" {
+			result[code] = fmt.Sprintf("Unknown%d", code)
+		}
+	}
+	return result
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+func (d *driftDetector) writeProposal() {
+	data, err := json.MarshalIndent(d.proposal(), "", "  ")
+	if err != nil {
+		log.Errorf("Could not encode the proposed code table: %v", err)
+		return
+	}
+
+	err = os.WriteFile(driftProposalFile, data, 0644)
+	if err != nil {
+		log.Errorf("Could not write the proposed code table: %v", err)
+		return
+	}
+
+	log.Warnf("A proposed code table was written to %v. Load it with -codes %v and please send it to the developers.", driftProposalFile, driftProposalFile)
+}
@@ -0,0 +1,298 @@
+package client
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+)
+
+type driftTestOrders struct {
+	Orders []string `mapstructure:"0" fingerprint:"json"`
+}
+
+func (driftTestOrders) Process(state *albionState) {}
+
+type driftTestMail struct {
+	ID   int    `mapstructure:"0"`
+	Body string `mapstructure:"1"`
+}
+
+func (driftTestMail) Process(state *albionState) {}
+
+type driftTestStats struct {
+	SkillIds []int   `mapstructure:"1"`
+	Levels   []int   `mapstructure:"2"`
+	Fame     []int64 `mapstructure:"3"`
+}
+
+func (driftTestStats) Process(state *albionState) {}
+
+func TestFingerprintStrong(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler operation
+		strong  bool
+	}{
+		{"json", driftTestOrders{}, true},
+		{"arrays", driftTestStats{}, true},
+		{"number and string", driftTestMail{}, false},
+		{"real ReadMail", &operationReadMail{}, false},
+		{"real GoldMarketGetAverageInfoResponse", &operationGoldMarketGetAverageInfoResponse{}, false},
+	}
+	for _, tt := range tests {
+		if got := newFingerprint(kindResponse, tt.name, tt.handler).strong(); got != tt.strong {
+			t.Errorf("%v: strong() = %v, want %v", tt.name, got, tt.strong)
+		}
+	}
+}
+
+func TestFingerprintMatches(t *testing.T) {
+	fp := newFingerprint(kindEvent, "CharacterStats", driftTestStats{})
+
+	tests := []struct {
+		name    string
+		params  map[uint8]interface{}
+		matches bool
+	}{
+		{"widths differ", map[uint8]interface{}{1: []int16{1}, 2: []int8{2}, 3: []int64{3}}, true},
+		{"extra params", map[uint8]interface{}{0: "x", 1: []int16{1}, 2: []int8{2}, 3: []int64{3}}, true},
+		{"missing param", map[uint8]interface{}{1: []int16{1}, 2: []int8{2}}, false},
+		{"wrong type", map[uint8]interface{}{1: []int16{1}, 2: []int8{2}, 3: "3"}, false},
+	}
+	for _, tt := range tests {
+		if got := fp.matches(tt.params); got != tt.matches {
+			t.Errorf("%v: matches() = %v, want %v", tt.name, got, tt.matches)
+		}
+	}
+
+	json := newFingerprint(kindResponse, "AuctionGetOffers", driftTestOrders{})
+	if json.matches(map[uint8]interface{}{0: []string{"not json"}}) {
+		t.Error("plain strings matched a JSON fingerprint")
+	}
+}
+
+// newTestDriftDetector builds a detector for the given response handlers,
+// writing its proposals to a temporary directory
+func newTestDriftDetector(t *testing.T, responses map[string]operation) *driftDetector {
+	t.Chdir(t.TempDir())
+
+	registry := newHandlerRegistry()
+	for name, handler := range responses {
+		handler := handler
+		registry.register(kindResponse, name, func() operation { return handler })
+	}
+	return newDriftDetector(registry)
+}
+
+func responseCode(t *testing.T, name string) int16 {
+	code, ok := codes.operation(name)
+	if !ok {
+		t.Fatalf("no operation %v in the code table", name)
+	}
+	return int16(code)
+}
+
+func movedTo(d *driftDetector, name string) (int16, bool) {
+	for fp, code := range d.moved {
+		if fp.name == name {
+			return code, true
+		}
+	}
+	return 0, false
+}
+
+func TestDriftDetectsMovedCode(t *testing.T) {
+	d := newTestDriftDetector(t, map[string]operation{"AuctionGetOffers": driftTestOrders{}})
+	moved := responseCode(t, "AuctionGetOffers") + 7
+	params := map[uint8]interface{}{253: moved, 0: []string{`{"Id":1}`}}
+
+	for i := 1; i < driftHits; i++ {
+		d.observe(kindResponse, params)
+	}
+	if _, ok := movedTo(d, "AuctionGetOffers"); ok {
+		t.Fatalf("moved after %d sightings, want %d", driftHits-1, driftHits)
+	}
+
+	d.observe(kindResponse, params)
+	if code, ok := movedTo(d, "AuctionGetOffers"); !ok || code != moved {
+		t.Fatalf("moved to %d (%v), want %d", code, ok, moved)
+	}
+	if name := d.proposal().Operations[moved]; name != "AuctionGetOffers" {
+		t.Errorf("proposed code %d is %v, want AuctionGetOffers", moved, name)
+	}
+	if _, err := os.Stat(driftProposalFile); err != nil {
+		t.Errorf("no proposal written: %v", err)
+	}
+}
+
+func TestDriftIgnoresWeakShape(t *testing.T) {
+	d := newTestDriftDetector(t, map[string]operation{"ReadMail": driftTestMail{}})
+	// another message with a number and a string, as many have
+	params := map[uint8]interface{}{253: responseCode(t, "ReadMail") + 3, 0: int32(5), 1: "hello"}
+
+	for i := 0; i < driftHits*2; i++ {
+		d.observe(kindResponse, params)
+	}
+	if len(d.moved) != 0 {
+		t.Errorf("a weak shape was taken for moved: %v", d.moved)
+	}
+	if _, err := os.Stat(driftProposalFile); !os.IsNotExist(err) {
+		t.Errorf("a proposal was written for a weak shape")
+	}
+}
+
+func TestDriftIgnoresMessagesWithMostParamsUnknown(t *testing.T) {
+	d := newTestDriftDetector(t, map[string]operation{"AuctionGetOffers": driftTestOrders{}})
+	params := map[uint8]interface{}{253: responseCode(t, "AuctionGetOffers") + 7, 0: []string{`{"Id":1}`}, 1: int8(1), 2: "x"}
+
+	for i := 0; i < driftHits*2; i++ {
+		d.observe(kindResponse, params)
+	}
+	if len(d.moved) != 0 {
+		t.Errorf("a message with more unknown than known params was taken for moved: %v", d.moved)
+	}
+}
+
+func TestDriftTieBreak(t *testing.T) {
+	d := newTestDriftDetector(t, map[string]operation{
+		"AuctionGetOffers":   driftTestOrders{},
+		"AuctionGetRequests": driftTestOrders{},
+	})
+	moved := responseCode(t, "AuctionGetOffers") - 50
+	params := map[uint8]interface{}{253: moved, 0: []string{`{"Id":1}`}}
+
+	// both shapes fit and nothing backs either offset, so the message says
+	// nothing
+	for i := 0; i < driftHits*2; i++ {
+		d.observe(kindResponse, params)
+	}
+	if len(d.moved) != 0 {
+		t.Fatalf("a shape shared by two handlers was taken for moved: %v", d.moved)
+	}
+
+	// once requests are seen at their code, only offers are left
+	d.observe(kindResponse, map[uint8]interface{}{253: responseCode(t, "AuctionGetRequests"), 0: []string{`{"Id":2}`}})
+	for i := 0; i < driftHits; i++ {
+		d.observe(kindResponse, params)
+	}
+	if code, ok := movedTo(d, "AuctionGetOffers"); !ok || code != moved {
+		t.Errorf("moved to %d (%v), want %d", code, ok, moved)
+	}
+	if _, ok := movedTo(d, "AuctionGetRequests"); ok {
+		t.Error("requests were taken for moved although seen at their code")
+	}
+}
+
+func TestDriftKeepsOrder(t *testing.T) {
+	d := newTestDriftDetector(t, map[string]operation{
+		"AuctionGetOffers":   driftTestOrders{},
+		"AuctionGetRequests": driftTestOrders{},
+	})
+	d.observe(kindResponse, map[uint8]interface{}{253: responseCode(t, "AuctionGetRequests"), 0: []string{`{"Id":2}`}})
+
+	// offers come before requests, they cannot have moved past them
+	params := map[uint8]interface{}{253: responseCode(t, "AuctionGetRequests") + 50, 0: []string{`{"Id":1}`}}
+	for i := 0; i < driftHits*2; i++ {
+		d.observe(kindResponse, params)
+	}
+	if len(d.moved) != 0 {
+		t.Errorf("a handler was taken for moved past one seen at its code: %v", d.moved)
+	}
+}
+
+// A patch that inserts codes before all handlers moves them all by the same
+// offset, none of them is seen at its old code
+func TestDriftAllCodesShift(t *testing.T) {
+	const shift = 4
+	d := newTestDriftDetector(t, map[string]operation{
+		"AuctionGetOffers":           driftTestOrders{},
+		"AuctionGetRequests":         driftTestOrders{},
+		"AuctionBuyOffer":            driftTestOrders{},
+		"AuctionGetItemAverageStats": driftTestStats{},
+	})
+	messages := map[string]map[uint8]interface{}{
+		"AuctionGetOffers":           {0: []string{`{"Id":1}`}},
+		"AuctionGetRequests":         {0: []string{`{"Id":2}`}},
+		"AuctionBuyOffer":            {0: []string{`{"Id":3}`}},
+		"AuctionGetItemAverageStats": {1: []int16{1}, 2: []int16{2}, 3: []int64{3}},
+	}
+	names := []string{"AuctionGetOffers", "AuctionGetRequests", "AuctionBuyOffer", "AuctionGetItemAverageStats"}
+
+	for round := 0; round < driftHits*2; round++ {
+		for _, name := range names {
+			params := messages[name]
+			params[253] = responseCode(t, name) + shift
+			d.observe(kindResponse, params)
+		}
+	}
+
+	proposal := d.proposal()
+	for _, name := range names {
+		want := responseCode(t, name) + shift
+		if code, ok := movedTo(d, name); !ok || code != want {
+			t.Errorf("%v moved to %d (%v), want %d", name, code, ok, want)
+		}
+		if got := proposal.Operations[want]; got != name {
+			t.Errorf("proposed code %d is %v, want %v", want, got, name)
+		}
+	}
+
+	// the names no message was seen for move along
+	for _, code := range []int{0, 80, len(codes.Operations) - 1} {
+		if got, want := proposal.Operations[code+shift], codes.Operations[code]; got != want {
+			t.Errorf("proposed code %d is %v, want %v", code+shift, got, want)
+		}
+	}
+	if _, err := parseCodeTable(mustMarshal(t, proposal)); err != nil {
+		t.Errorf("the proposal is not a valid code table: %v", err)
+	}
+}
+
+func TestShiftNames(t *testing.T) {
+	names := []string{"a", "b", "c", "d", "e", "f", "g", "h"}
+
+	got := shiftNames(names, map[int]int{1: 0, 5: 2})
+	want := []string{"a", "b", "c", "d", "Unknown4", "Unknown5", "e", "f", "g", "h"}
+	if strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("inserted codes: got %v, want %v", got, want)
+	}
+
+	// e is nearer its anchor than d, d is left out
+	got = shiftNames(names, map[int]int{1: 0, 5: -1})
+	want = []string{"a", "b", "c", "e", "f", "g", "h"}
+	if strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("removed codes: got %v, want %v", got, want)
+	}
+
+	if got := shiftNames(names, nil); strings.Join(got, " ") != strings.Join(names, " ") {
+		t.Errorf("without anchors: got %v, want %v", got, names)
+	}
+}
+
+func TestBestCandidatesPreferTheMostSpecific(t *testing.T) {
+	small := newFingerprint(kindEvent, "small", driftTestOrders{})
+	other := newFingerprint(kindEvent, "other", driftTestOrders{})
+	big := newFingerprint(kindEvent, "big", driftTestStats{})
+	big.params[0] = paramJSONSlice
+	params := map[uint8]interface{}{0: []string{`{}`}, 1: []int8{1}, 2: []int8{2}, 3: []int8{3}}
+
+	if got := bestCandidates([]*fingerprint{small, big}, params); len(got) != 1 || got[0] != big {
+		t.Errorf("bestCandidates() = %v, want %v", got, big)
+	}
+	if got := bestCandidates([]*fingerprint{small, other}, params); len(got) != 2 {
+		t.Errorf("bestCandidates() of a tie = %v, want both", got)
+	}
+	if got := bestCandidates(nil, params); got != nil {
+		t.Errorf("bestCandidates() of none = %v, want nil", got)
+	}
+}
+
+func mustMarshal(t *testing.T, v interface{}) []byte {
+	t.Helper()
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
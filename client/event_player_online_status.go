@@ -5,6 +5,8 @@ import (
 	"github.com/ao-data/albiondata-client/log"
 )
 
+// eventPlayerOnlineStatus is not registered for an event, no capture
+// confirms which code carries it. See testdata/README.md.
 type eventPlayerOnlineStatus struct {
 	CharacterID   lib.CharacterID `mapstructure:"0"`
 	CharacterName string          `mapstructure:"1"`
@@ -12,5 +14,5 @@ type eventPlayerOnlineStatus struct {
 }
 
 func (event eventPlayerOnlineStatus) Process(state *albionState) {
-	log.Debug("Got player online status event...")
+	log.Debugf("Got player online status event, %v (%v) online: %v", event.CharacterName, event.CharacterID, event.IsOnline)
 }
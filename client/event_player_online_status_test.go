@@ -0,0 +1,29 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/ao-data/albiondata-client/lib"
+)
+
+func TestEventPlayerOnlineStatusDecodes(t *testing.T) {
+	event := &eventPlayerOnlineStatus{}
+	err := event.decode(map[uint8]interface{}{
+		0: []int8{103, 69, 35, 1, -85, -119, -17, -51, 1, 35, 69, 103, -119, -85, -51, -17},
+		1: "Tester",
+		2: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := lib.CharacterID("01234567-89ab-cdef-0123-456789abcdef"); event.CharacterID != want {
+		t.Errorf("CharacterID = %v, want %v", event.CharacterID, want)
+	}
+	if event.CharacterName != "Tester" {
+		t.Errorf("CharacterName = %q, want Tester", event.CharacterName)
+	}
+	if !event.IsOnline {
+		t.Error("IsOnline = false, want true")
+	}
+}
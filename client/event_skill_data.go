@@ -2,12 +2,15 @@ package client
 
 import (
 	"strconv"
+	"strings"
 
 	"github.com/ao-data/albiondata-client/lib"
 	"github.com/ao-data/albiondata-client/log"
 	uuid "github.com/nu7hatch/gouuid"
 )
 
+// eventSkillData is not registered for an event, no capture confirms which
+// code carries it. See testdata/README.md.
 type eventSkillData struct {
 	SkillIds    []int     `mapstructure:"1"`
 	Levels      []int     `mapstructure:"2"`
@@ -18,6 +21,14 @@ type eventSkillData struct {
 func (event eventSkillData) Process(state *albionState) {
 	log.Debug("Got skill data event...")
 
+	// All arrays are indexed by skill, anything else means the event code
+	// does not point at the skill data anymore
+	count := len(event.SkillIds)
+	if len(event.Levels) != count || len(event.Percentages) != count || len(event.Fame) != count {
+		log.Debugf("Skill data arrays have different lengths (%d, %d, %d, %d), ignoring event", count, len(event.Levels), len(event.Percentages), len(event.Fame))
+		return
+	}
+
 	skills := []*lib.Skill{}
 
 	for k := range event.SkillIds {
@@ -26,7 +37,7 @@ func (event eventSkillData) Process(state *albionState) {
 		skill.Level = event.Levels[k]
 		skill.PercentNextLevel = event.Percentages[k]
 		// for some reason, the value is enclosed in [[]]. trying to get rid of them
-		fame, err := strconv.Atoi(event.Fame[k][2 : len(event.Fame[k])-2])
+		fame, err := strconv.Atoi(strings.Trim(event.Fame[k], "[]"))
 		if err != nil {
 			log.Error("Could not parse fame value. ", err)
 			continue
@@ -45,6 +56,6 @@ func (event eventSkillData) Process(state *albionState) {
 	}
 
 	identifier, _ := uuid.NewV4()
-	log.Infof("Sending %d skills of %v to ingest", len(skills), state.CharacterName)
+	log.Infof("Sending %d skills of %v to ingest", len(skills), state.snapshot().CharacterName)
 	sendMsgToPrivateUploaders(&upload, lib.NatsSkillData, state, identifier.String())
 }
@@ -0,0 +1,43 @@
+package client
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEventSkillDataDecodes(t *testing.T) {
+	event := &eventSkillData{}
+	err := event.decode(map[uint8]interface{}{
+		1: []int16{1, 2, 301},
+		2: []int8{100, 45, 3},
+		3: []float32{0, 0.5, 0.25},
+		4: []string{"[[1234567]]", "[[89000]]", "[[0]]"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := []int{1, 2, 301}; !reflect.DeepEqual(event.SkillIds, want) {
+		t.Errorf("SkillIds = %v, want %v", event.SkillIds, want)
+	}
+	if want := []int{100, 45, 3}; !reflect.DeepEqual(event.Levels, want) {
+		t.Errorf("Levels = %v, want %v", event.Levels, want)
+	}
+	if want := []float64{0, 0.5, 0.25}; !reflect.DeepEqual(event.Percentages, want) {
+		t.Errorf("Percentages = %v, want %v", event.Percentages, want)
+	}
+	if want := []string{"[[1234567]]", "[[89000]]", "[[0]]"}; !reflect.DeepEqual(event.Fame, want) {
+		t.Errorf("Fame = %v, want %v", event.Fame, want)
+	}
+}
+
+func TestEventsWithoutCaptureAreNotRegistered(t *testing.T) {
+	for _, name := range handlers.names(kindEvent) {
+		for _, factory := range handlers.handlers[kindEvent][name] {
+			switch factory().(type) {
+			case *eventSkillData, *eventPlayerOnlineStatus:
+				t.Errorf("%T is registered for %v, but no captured fixture confirms it", factory(), name)
+			}
+		}
+	}
+}
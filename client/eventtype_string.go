@@ -1,684 +0,0 @@
-// Code generated by "stringer -type=EventType"; DO NOT EDIT.
-
-package client
-
-import "strconv"
-
-func _() {
-	// An "invalid array index" compiler error signifies that the constant values have changed.
-	// Re-run the stringer command to generate them again.
-	var x [1]struct{}
-	_ = x[evUnused-0]
-	_ = x[evLeave-1]
-	_ = x[evJoinFinished-2]
-	_ = x[evMove-3]
-	_ = x[evTeleport-4]
-	_ = x[evChangeEquipment-5]
-	_ = x[evHealthUpdate-6]
-	_ = x[evHealthUpdates-7]
-	_ = x[evEnergyUpdate-8]
-	_ = x[evDamageShieldUpdate-9]
-	_ = x[evCraftingFocusUpdate-10]
-	_ = x[evActiveSpellEffectsUpdate-11]
-	_ = x[evResetCooldowns-12]
-	_ = x[evAttack-13]
-	_ = x[evCastStart-14]
-	_ = x[evChannelingUpdate-15]
-	_ = x[evCastCancel-16]
-	_ = x[evCastTimeUpdate-17]
-	_ = x[evCastFinished-18]
-	_ = x[evCastSpell-19]
-	_ = x[evCastSpells-20]
-	_ = x[evCastHit-21]
-	_ = x[evCastHits-22]
-	_ = x[evStoredTargetsUpdate-23]
-	_ = x[evChannelingEnded-24]
-	_ = x[evAttackBuilding-25]
-	_ = x[evInventoryPutItem-26]
-	_ = x[evInventoryDeleteItem-27]
-	_ = x[evInventoryState-28]
-	_ = x[evNewCharacter-29]
-	_ = x[evNewEquipmentItem-30]
-	_ = x[evNewSiegeBannerItem-31]
-	_ = x[evNewSimpleItem-32]
-	_ = x[evNewFurnitureItem-33]
-	_ = x[evNewKillTrophyItem-34]
-	_ = x[evNewJournalItem-35]
-	_ = x[evNewLaborerItem-36]
-	_ = x[evNewEquipmentItemLegendarySoul-37]
-	_ = x[evNewSimpleHarvestableObject-38]
-	_ = x[evNewSimpleHarvestableObjectList-39]
-	_ = x[evNewHarvestableObject-40]
-	_ = x[evNewTreasureDestinationObject-41]
-	_ = x[evTreasureDestinationObjectStatus-42]
-	_ = x[evCloseTreasureDestinationObject-43]
-	_ = x[evNewSilverObject-44]
-	_ = x[evNewBuilding-45]
-	_ = x[evHarvestableChangeState-46]
-	_ = x[evMobChangeState-47]
-	_ = x[evFactionBuildingInfo-48]
-	_ = x[evCraftBuildingInfo-49]
-	_ = x[evRepairBuildingInfo-50]
-	_ = x[evMeldBuildingInfo-51]
-	_ = x[evConstructionSiteInfo-52]
-	_ = x[evPlayerBuildingInfo-53]
-	_ = x[evFarmBuildingInfo-54]
-	_ = x[evTutorialBuildingInfo-55]
-	_ = x[evLaborerObjectInfo-56]
-	_ = x[evLaborerObjectJobInfo-57]
-	_ = x[evMarketPlaceBuildingInfo-58]
-	_ = x[evHarvestStart-59]
-	_ = x[evHarvestCancel-60]
-	_ = x[evHarvestFinished-61]
-	_ = x[evTakeSilver-62]
-	_ = x[evRemoveSilver-63]
-	_ = x[evActionOnBuildingStart-64]
-	_ = x[evActionOnBuildingCancel-65]
-	_ = x[evActionOnBuildingFinished-66]
-	_ = x[evItemRerollQualityFinished-67]
-	_ = x[evInstallResourceStart-68]
-	_ = x[evInstallResourceCancel-69]
-	_ = x[evInstallResourceFinished-70]
-	_ = x[evCraftItemFinished-71]
-	_ = x[evLogoutCancel-72]
-	_ = x[evChatMessage-73]
-	_ = x[evChatSay-74]
-	_ = x[evChatWhisper-75]
-	_ = x[evChatMuted-76]
-	_ = x[evPlayEmote-77]
-	_ = x[evStopEmote-78]
-	_ = x[evSystemMessage-79]
-	_ = x[evUtilityTextMessage-80]
-	_ = x[evUpdateMoney-81]
-	_ = x[evUpdateFame-82]
-	_ = x[evUpdateLearningPoints-83]
-	_ = x[evUpdateReSpecPoints-84]
-	_ = x[evUpdateCurrency-85]
-	_ = x[evUpdateFactionStanding-86]
-	_ = x[evUpdateStanding-87]
-	_ = x[evRespawn-88]
-	_ = x[evServerDebugLog-89]
-	_ = x[evCharacterEquipmentChanged-90]
-	_ = x[evRegenerationHealthChanged-91]
-	_ = x[evRegenerationEnergyChanged-92]
-	_ = x[evRegenerationMountHealthChanged-93]
-	_ = x[evRegenerationCraftingChanged-94]
-	_ = x[evRegenerationHealthEnergyComboChanged-95]
-	_ = x[evRegenerationPlayerComboChanged-96]
-	_ = x[evDurabilityChanged-97]
-	_ = x[evNewLoot-98]
-	_ = x[evAttachItemContainer-99]
-	_ = x[evDetachItemContainer-100]
-	_ = x[evInvalidateItemContainer-101]
-	_ = x[evLockItemContainer-102]
-	_ = x[evGuildUpdate-103]
-	_ = x[evGuildPlayerUpdated-104]
-	_ = x[evInvitedToGuild-105]
-	_ = x[evGuildMemberWorldUpdate-106]
-	_ = x[evUpdateMatchDetails-107]
-	_ = x[evObjectEvent-108]
-	_ = x[evNewMonolithObject-109]
-	_ = x[evMonolithHasBannersPlacedUpdate-110]
-	_ = x[evNewOrbObject-111]
-	_ = x[evNewCastleObject-112]
-	_ = x[evNewSpellEffectArea-113]
-	_ = x[evUpdateSpellEffectArea-114]
-	_ = x[evNewChainSpell-115]
-	_ = x[evUpdateChainSpell-116]
-	_ = x[evNewTreasureChest-117]
-	_ = x[evStartMatch-118]
-	_ = x[evStartArenaMatchInfos-119]
-	_ = x[evEndArenaMatch-120]
-	_ = x[evMatchUpdate-121]
-	_ = x[evActiveMatchUpdate-122]
-	_ = x[evNewMob-123]
-	_ = x[evDebugMobInfo-124]
-	_ = x[evDebugVariablesInfo-125]
-	_ = x[evDebugReputationInfo-126]
-	_ = x[evDebugDiminishingReturnInfo-127]
-	_ = x[evDebugSmartClusterQueueInfo-128]
-	_ = x[evClaimOrbStart-129]
-	_ = x[evClaimOrbFinished-130]
-	_ = x[evClaimOrbCancel-131]
-	_ = x[evOrbUpdate-132]
-	_ = x[evOrbClaimed-133]
-	_ = x[evOrbReset-134]
-	_ = x[evNewWarCampObject-135]
-	_ = x[evNewMatchLootChestObject-136]
-	_ = x[evNewArenaExit-137]
-	_ = x[evGuildMemberTerritoryUpdate-138]
-	_ = x[evInvitedMercenaryToMatch-139]
-	_ = x[evClusterInfoUpdate-140]
-	_ = x[evForcedMovement-141]
-	_ = x[evForcedMovementCancel-142]
-	_ = x[evCharacterStats-143]
-	_ = x[evCharacterStatsKillHistory-144]
-	_ = x[evCharacterStatsDeathHistory-145]
-	_ = x[evCharacterStatsKnockDownHistory-146]
-	_ = x[evCharacterStatsKnockedDownHistory-147]
-	_ = x[evGuildStats-148]
-	_ = x[evKillHistoryDetails-149]
-	_ = x[evItemKillHistoryDetails-150]
-	_ = x[evFullAchievementInfo-151]
-	_ = x[evFinishedAchievement-152]
-	_ = x[evAchievementProgressInfo-153]
-	_ = x[evFullAchievementProgressInfo-154]
-	_ = x[evFullTrackedAchievementInfo-155]
-	_ = x[evFullAutoLearnAchievementInfo-156]
-	_ = x[evQuestGiverQuestOffered-157]
-	_ = x[evQuestGiverDebugInfo-158]
-	_ = x[evConsoleEvent-159]
-	_ = x[evTimeSync-160]
-	_ = x[evChangeAvatar-161]
-	_ = x[evChangeMountSkin-162]
-	_ = x[evGameEvent-163]
-	_ = x[evKilledPlayer-164]
-	_ = x[evDied-165]
-	_ = x[evKnockedDown-166]
-	_ = x[evUnconcious-167]
-	_ = x[evMatchPlayerJoinedEvent-168]
-	_ = x[evMatchPlayerStatsEvent-169]
-	_ = x[evMatchPlayerStatsCompleteEvent-170]
-	_ = x[evMatchTimeLineEventEvent-171]
-	_ = x[evMatchPlayerMainGearStatsEvent-172]
-	_ = x[evMatchPlayerChangedAvatarEvent-173]
-	_ = x[evInvitationPlayerTrade-174]
-	_ = x[evPlayerTradeStart-175]
-	_ = x[evPlayerTradeCancel-176]
-	_ = x[evPlayerTradeUpdate-177]
-	_ = x[evPlayerTradeFinished-178]
-	_ = x[evPlayerTradeAcceptChange-179]
-	_ = x[evMiniMapPing-180]
-	_ = x[evMarketPlaceNotification-181]
-	_ = x[evDuellingChallengePlayer-182]
-	_ = x[evNewDuellingPost-183]
-	_ = x[evDuelStarted-184]
-	_ = x[evDuelEnded-185]
-	_ = x[evDuelDenied-186]
-	_ = x[evDuelRequestCanceled-187]
-	_ = x[evDuelLeftArea-188]
-	_ = x[evDuelReEnteredArea-189]
-	_ = x[evNewRealEstate-190]
-	_ = x[evMiniMapOwnedBuildingsPositions-191]
-	_ = x[evRealEstateListUpdate-192]
-	_ = x[evGuildLogoUpdate-193]
-	_ = x[evGuildLogoChanged-194]
-	_ = x[evPlaceableObjectPlace-195]
-	_ = x[evPlaceableObjectPlaceCancel-196]
-	_ = x[evFurnitureObjectBuffProviderInfo-197]
-	_ = x[evFurnitureObjectCheatProviderInfo-198]
-	_ = x[evFarmableObjectInfo-199]
-	_ = x[evNewUnreadMails-200]
-	_ = x[evMailOperationPossible-201]
-	_ = x[evGuildLogoObjectUpdate-202]
-	_ = x[evStartLogout-203]
-	_ = x[evNewChatChannels-204]
-	_ = x[evJoinedChatChannel-205]
-	_ = x[evLeftChatChannel-206]
-	_ = x[evRemovedChatChannel-207]
-	_ = x[evAccessStatus-208]
-	_ = x[evMounted-209]
-	_ = x[evMountStart-210]
-	_ = x[evMountCancel-211]
-	_ = x[evNewTravelpoint-212]
-	_ = x[evNewIslandAccessPoint-213]
-	_ = x[evNewExit-214]
-	_ = x[evUpdateHome-215]
-	_ = x[evUpdateChatSettings-216]
-	_ = x[evResurrectionOffer-217]
-	_ = x[evResurrectionReply-218]
-	_ = x[evLootEquipmentChanged-219]
-	_ = x[evUpdateUnlockedGuildLogos-220]
-	_ = x[evUpdateUnlockedAvatars-221]
-	_ = x[evUpdateUnlockedAvatarRings-222]
-	_ = x[evUpdateUnlockedBuildings-223]
-	_ = x[evNewIslandManagement-224]
-	_ = x[evNewTeleportStone-225]
-	_ = x[evCloak-226]
-	_ = x[evPartyInvitation-227]
-	_ = x[evPartyJoinRequest-228]
-	_ = x[evPartyJoined-229]
-	_ = x[evPartyDisbanded-230]
-	_ = x[evPartyPlayerJoined-231]
-	_ = x[evPartyChangedOrder-232]
-	_ = x[evPartyPlayerLeft-233]
-	_ = x[evPartyLeaderChanged-234]
-	_ = x[evPartyLootSettingChangedPlayer-235]
-	_ = x[evPartySilverGained-236]
-	_ = x[evPartyPlayerUpdated-237]
-	_ = x[evPartyInvitationAnswer-238]
-	_ = x[evPartyJoinRequestAnswer-239]
-	_ = x[evPartyMarkedObjectsUpdated-240]
-	_ = x[evPartyOnClusterPartyJoined-241]
-	_ = x[evPartySetRoleFlag-242]
-	_ = x[evPartyInviteOrJoinPlayerEquipmentInfo-243]
-	_ = x[evPartyReadyCheckUpdate-244]
-	_ = x[evPartyFactionWarfareReinforcementSettingChangedPlayer-245]
-	_ = x[evSpellCooldownUpdate-246]
-	_ = x[evNewHellgateExitPortal-247]
-	_ = x[evNewExpeditionExit-248]
-	_ = x[evNewExpeditionNarrator-249]
-	_ = x[evExitEnterStart-250]
-	_ = x[evExitEnterCancel-251]
-	_ = x[evExitEnterFinished-252]
-	_ = x[evNewQuestGiverObject-253]
-	_ = x[evFullQuestInfo-254]
-	_ = x[evQuestProgressInfo-255]
-	_ = x[evQuestGiverInfoForPlayer-256]
-	_ = x[evFullExpeditionInfo-257]
-	_ = x[evExpeditionQuestProgressInfo-258]
-	_ = x[evInvitedToExpedition-259]
-	_ = x[evExpeditionRegistrationInfo-260]
-	_ = x[evEnteringExpeditionStart-261]
-	_ = x[evEnteringExpeditionCancel-262]
-	_ = x[evRewardGranted-263]
-	_ = x[evArenaRegistrationInfo-264]
-	_ = x[evEnteringArenaStart-265]
-	_ = x[evEnteringArenaCancel-266]
-	_ = x[evEnteringArenaLockStart-267]
-	_ = x[evEnteringArenaLockCancel-268]
-	_ = x[evInvitedToArenaMatch-269]
-	_ = x[evUsingHellgateShrine-270]
-	_ = x[evEnteringHellgateLockStart-271]
-	_ = x[evEnteringHellgateLockCancel-272]
-	_ = x[evPlayerCounts-273]
-	_ = x[evInCombatStateUpdate-274]
-	_ = x[evOtherGrabbedLoot-275]
-	_ = x[evTreasureChestUsingStart-276]
-	_ = x[evTreasureChestUsingFinished-277]
-	_ = x[evTreasureChestUsingCancel-278]
-	_ = x[evTreasureChestUsingOpeningComplete-279]
-	_ = x[evTreasureChestForceCloseInventory-280]
-	_ = x[evLocalTreasuresUpdate-281]
-	_ = x[evLootChestSpawnpointsUpdate-282]
-	_ = x[evPremiumChanged-283]
-	_ = x[evPremiumExtended-284]
-	_ = x[evPremiumLifeTimeRewardGained-285]
-	_ = x[evGoldPurchased-286]
-	_ = x[evLaborerGotUpgraded-287]
-	_ = x[evJournalGotFull-288]
-	_ = x[evJournalFillError-289]
-	_ = x[evFriendRequest-290]
-	_ = x[evFriendRequestInfos-291]
-	_ = x[evFriendInfos-292]
-	_ = x[evFriendRequestAnswered-293]
-	_ = x[evFriendOnlineStatus-294]
-	_ = x[evFriendRequestCanceled-295]
-	_ = x[evFriendRemoved-296]
-	_ = x[evFriendUpdated-297]
-	_ = x[evPartyLootItems-298]
-	_ = x[evPartyLootItemsRemoved-299]
-	_ = x[evPartyLootItemTypesRemoved-300]
-	_ = x[evReputationUpdate-301]
-	_ = x[evDefenseUnitAttackBegin-302]
-	_ = x[evDefenseUnitAttackEnd-303]
-	_ = x[evDefenseUnitAttackDamage-304]
-	_ = x[evUnrestrictedPvpZoneUpdate-305]
-	_ = x[evUnrestrictedPvpZoneStatus-306]
-	_ = x[evReputationImplicationUpdate-307]
-	_ = x[evNewMountObject-308]
-	_ = x[evMountHealthUpdate-309]
-	_ = x[evMountCooldownUpdate-310]
-	_ = x[evNewExpeditionAgent-311]
-	_ = x[evNewExpeditionCheckPoint-312]
-	_ = x[evExpeditionStartEvent-313]
-	_ = x[evVoteEvent-314]
-	_ = x[evRatingEvent-315]
-	_ = x[evNewArenaAgent-316]
-	_ = x[evBoostFarmable-317]
-	_ = x[evUseFunction-318]
-	_ = x[evNewPortalEntrance-319]
-	_ = x[evNewPortalExit-320]
-	_ = x[evNewRandomDungeonExit-321]
-	_ = x[evWaitingQueueUpdate-322]
-	_ = x[evPlayerMovementRateUpdate-323]
-	_ = x[evObserveStart-324]
-	_ = x[evMinimapZergs-325]
-	_ = x[evMinimapSmartClusterZergs-326]
-	_ = x[evPaymentTransactions-327]
-	_ = x[evPerformanceStatsUpdate-328]
-	_ = x[evOverloadModeUpdate-329]
-	_ = x[evDebugDrawEvent-330]
-	_ = x[evRecordCameraMove-331]
-	_ = x[evRecordStart-332]
-	_ = x[evDeliverCarriableObjectStart-333]
-	_ = x[evDeliverCarriableObjectCancel-334]
-	_ = x[evDeliverCarriableObjectReset-335]
-	_ = x[evDeliverCarriableObjectFinished-336]
-	_ = x[evTerritoryClaimStart-337]
-	_ = x[evTerritoryClaimCancel-338]
-	_ = x[evTerritoryClaimFinished-339]
-	_ = x[evTerritoryScheduleResult-340]
-	_ = x[evTerritoryUpgradeWithPowerCrystalResult-341]
-	_ = x[evReceiveCarriableObjectStart-342]
-	_ = x[evReceiveCarriableObjectFinished-343]
-	_ = x[evUpdateAccountState-344]
-	_ = x[evStartDeterministicRoam-345]
-	_ = x[evGuildFullAccessTagsUpdated-346]
-	_ = x[evGuildAccessTagUpdated-347]
-	_ = x[evGvgSeasonUpdate-348]
-	_ = x[evGvgSeasonCheatCommand-349]
-	_ = x[evSeasonPointsByKillingBooster-350]
-	_ = x[evFishingStart-351]
-	_ = x[evFishingCast-352]
-	_ = x[evFishingCatch-353]
-	_ = x[evFishingFinished-354]
-	_ = x[evFishingCancel-355]
-	_ = x[evNewFloatObject-356]
-	_ = x[evNewFishingZoneObject-357]
-	_ = x[evFishingMiniGame-358]
-	_ = x[evAlbionJournalAchievementCompleted-359]
-	_ = x[evUpdatePuppet-360]
-	_ = x[evChangeFlaggingFinished-361]
-	_ = x[evNewOutpostObject-362]
-	_ = x[evOutpostUpdate-363]
-	_ = x[evOutpostClaimed-364]
-	_ = x[evOverChargeEnd-365]
-	_ = x[evOverChargeStatus-366]
-	_ = x[evPartyFinderFullUpdate-367]
-	_ = x[evPartyFinderUpdate-368]
-	_ = x[evPartyFinderApplicantsUpdate-369]
-	_ = x[evPartyFinderEquipmentSnapshot-370]
-	_ = x[evPartyFinderJoinRequestDeclined-371]
-	_ = x[evNewUnlockedPersonalSeasonRewards-372]
-	_ = x[evPersonalSeasonPointsGained-373]
-	_ = x[evPersonalSeasonPastSeasonDataEvent-374]
-	_ = x[evMatchLootChestOpeningStart-375]
-	_ = x[evMatchLootChestOpeningFinished-376]
-	_ = x[evMatchLootChestOpeningCancel-377]
-	_ = x[evNotifyCrystalMatchReward-378]
-	_ = x[evCrystalRealmFeedback-379]
-	_ = x[evNewLocationMarker-380]
-	_ = x[evNewTutorialBlocker-381]
-	_ = x[evNewTileSwitch-382]
-	_ = x[evNewInformationProvider-383]
-	_ = x[evNewDynamicGuildLogo-384]
-	_ = x[evNewDecoration-385]
-	_ = x[evTutorialUpdate-386]
-	_ = x[evTriggerHintBox-387]
-	_ = x[evRandomDungeonPositionInfo-388]
-	_ = x[evNewLootChest-389]
-	_ = x[evUpdateLootChest-390]
-	_ = x[evLootChestOpened-391]
-	_ = x[evUpdateLootProtectedByMobsWithMinimapDisplay-392]
-	_ = x[evNewShrine-393]
-	_ = x[evUpdateShrine-394]
-	_ = x[evUpdateRoom-395]
-	_ = x[evNewMobSoul-396]
-	_ = x[evNewHellgateShrine-397]
-	_ = x[evUpdateHellgateShrine-398]
-	_ = x[evActivateHellgateExit-399]
-	_ = x[evMutePlayerUpdate-400]
-	_ = x[evShopTileUpdate-401]
-	_ = x[evShopUpdate-402]
-	_ = x[evAntiCheatKick-403]
-	_ = x[evBattlEyeServerMessage-404]
-	_ = x[evUnlockVanityUnlock-405]
-	_ = x[evAvatarUnlocked-406]
-	_ = x[evCustomizationChanged-407]
-	_ = x[evBaseVaultInfo-408]
-	_ = x[evGuildVaultInfo-409]
-	_ = x[evBankVaultInfo-410]
-	_ = x[evRecoveryVaultPlayerInfo-411]
-	_ = x[evRecoveryVaultGuildInfo-412]
-	_ = x[evUpdateWardrobe-413]
-	_ = x[evCastlePhaseChanged-414]
-	_ = x[evGuildAccountLogEvent-415]
-	_ = x[evNewHideoutObject-416]
-	_ = x[evNewHideoutManagement-417]
-	_ = x[evNewHideoutExit-418]
-	_ = x[evInitHideoutAttackStart-419]
-	_ = x[evInitHideoutAttackCancel-420]
-	_ = x[evInitHideoutAttackFinished-421]
-	_ = x[evHideoutManagementUpdate-422]
-	_ = x[evHideoutUpgradeWithPowerCrystalResult-423]
-	_ = x[evIpChanged-424]
-	_ = x[evSmartClusterQueueUpdateInfo-425]
-	_ = x[evSmartClusterQueueActiveInfo-426]
-	_ = x[evSmartClusterQueueKickWarning-427]
-	_ = x[evSmartClusterQueueInvite-428]
-	_ = x[evReceivedGvgSeasonPoints-429]
-	_ = x[evTowerPowerPointUpdate-430]
-	_ = x[evOpenWorldAttackScheduleStart-431]
-	_ = x[evOpenWorldAttackScheduleFinished-432]
-	_ = x[evOpenWorldAttackScheduleCancel-433]
-	_ = x[evOpenWorldAttackConquerStart-434]
-	_ = x[evOpenWorldAttackConquerFinished-435]
-	_ = x[evOpenWorldAttackConquerCancel-436]
-	_ = x[evOpenWorldAttackConquerStatus-437]
-	_ = x[evOpenWorldAttackStart-438]
-	_ = x[evOpenWorldAttackEnd-439]
-	_ = x[evNewRandomResourceBlocker-440]
-	_ = x[evNewHomeObject-441]
-	_ = x[evHideoutObjectUpdate-442]
-	_ = x[evUpdateInfamy-443]
-	_ = x[evMinimapPositionMarkers-444]
-	_ = x[evNewTunnelExit-445]
-	_ = x[evCorruptedDungeonUpdate-446]
-	_ = x[evCorruptedDungeonStatus-447]
-	_ = x[evCorruptedDungeonInfamy-448]
-	_ = x[evHellgateRestrictedAreaUpdate-449]
-	_ = x[evHellgateInfamy-450]
-	_ = x[evHellgateStatus-451]
-	_ = x[evHellgateStatusUpdate-452]
-	_ = x[evHellgateSuspense-453]
-	_ = x[evReplaceSpellSlotWithMultiSpell-454]
-	_ = x[evNewCorruptedShrine-455]
-	_ = x[evUpdateCorruptedShrine-456]
-	_ = x[evCorruptedShrineUsageStart-457]
-	_ = x[evCorruptedShrineUsageCancel-458]
-	_ = x[evExitUsed-459]
-	_ = x[evLinkedToObject-460]
-	_ = x[evLinkToObjectBroken-461]
-	_ = x[evEstimatedMarketValueUpdate-462]
-	_ = x[evStuckCancel-463]
-	_ = x[evDungonEscapeReady-464]
-	_ = x[evFactionWarfareClusterState-465]
-	_ = x[evFactionWarfareHasUnclaimedWeeklyReportsEvent-466]
-	_ = x[evSimpleFeedback-467]
-	_ = x[evSmartClusterQueueSkipClusterError-468]
-	_ = x[evXignCodeEvent-469]
-	_ = x[evBatchUseItemStart-470]
-	_ = x[evBatchUseItemEnd-471]
-	_ = x[evRedZoneEventClusterStatus-472]
-	_ = x[evRedZonePlayerNotification-473]
-	_ = x[evRedZoneWorldEvent-474]
-	_ = x[evFactionWarfareStats-475]
-	_ = x[evUpdateFactionBalanceFactors-476]
-	_ = x[evFactionEnlistmentChanged-477]
-	_ = x[evUpdateFactionRank-478]
-	_ = x[evFactionWarfareCampaignRewardsUnlocked-479]
-	_ = x[evFeaturedFeatureUpdate-480]
-	_ = x[evNewCarriableObject-481]
-	_ = x[evMinimapCrystalPositionMarker-482]
-	_ = x[evCarriedObjectUpdate-483]
-	_ = x[evPickupCarriableObjectStart-484]
-	_ = x[evPickupCarriableObjectCancel-485]
-	_ = x[evPickupCarriableObjectFinished-486]
-	_ = x[evDoSimpleActionStart-487]
-	_ = x[evDoSimpleActionCancel-488]
-	_ = x[evDoSimpleActionFinished-489]
-	_ = x[evNotifyGuestAccountVerified-490]
-	_ = x[evMightAndFavorReceivedEvent-491]
-	_ = x[evWeeklyPvpChallengeRewardStateUpdate-492]
-	_ = x[evNewUnlockedPvpSeasonChallengeRewards-493]
-	_ = x[evStaticDungeonEntrancesDungeonEventStatusUpdates-494]
-	_ = x[evStaticDungeonDungeonValueUpdate-495]
-	_ = x[evStaticDungeonEntranceDungeonEventsAborted-496]
-	_ = x[evInAppPurchaseConfirmedGooglePlay-497]
-	_ = x[evFeatureSwitchInfo-498]
-	_ = x[evPartyJoinRequestAborted-499]
-	_ = x[evPartyInviteAborted-500]
-	_ = x[evPartyStartHuntRequest-501]
-	_ = x[evPartyStartHuntRequested-502]
-	_ = x[evPartyStartHuntRequestAnswer-503]
-	_ = x[evPartyPlayerLeaveScheduled-504]
-	_ = x[evGuildInviteDeclined-505]
-	_ = x[evCancelMultiSpellSlots-506]
-	_ = x[evNewVisualEventObject-507]
-	_ = x[evCastleClaimProgress-508]
-	_ = x[evCastleClaimProgressLogo-509]
-	_ = x[evTownPortalUpdateState-510]
-	_ = x[evTownPortalFailed-511]
-	_ = x[evConsumableVanityChargesAdded-512]
-	_ = x[evFestivitiesUpdate-513]
-	_ = x[evNewBannerObject-514]
-	_ = x[evNewMistsImmediateReturnExit-515]
-	_ = x[evMistsPlayerJoinedInfo-516]
-	_ = x[evNewMistsStaticEntrance-517]
-	_ = x[evNewMistsOpenWorldExit-518]
-	_ = x[evNewTunnelExitTemp-519]
-	_ = x[evNewMistsWispSpawn-520]
-	_ = x[evMistsWispSpawnStateChange-521]
-	_ = x[evNewMistsCityEntrance-522]
-	_ = x[evNewMistsCityRoadsEntrance-523]
-	_ = x[evMistsCityRoadsEntrancePartyStateUpdate-524]
-	_ = x[evMistsCityRoadsEntranceClearStateForParty-525]
-	_ = x[evMistsEntranceDataChanged-526]
-	_ = x[evNewCagedObject-527]
-	_ = x[evCagedObjectStateUpdated-528]
-	_ = x[evEntrancePartyBindingCreated-529]
-	_ = x[evEntrancePartyBindingCleared-530]
-	_ = x[evEntrancePartyBindingInfos-531]
-	_ = x[evNewMistsBorderExit-532]
-	_ = x[evNewMistsDungeonExit-533]
-	_ = x[evLocalQuestInfos-534]
-	_ = x[evLocalQuestStarted-535]
-	_ = x[evLocalQuestActive-536]
-	_ = x[evLocalQuestInactive-537]
-	_ = x[evLocalQuestProgressUpdate-538]
-	_ = x[evNewUnrestrictedPvpZone-539]
-	_ = x[evTemporaryFlaggingStatusUpdate-540]
-	_ = x[evSpellTestPerformanceUpdate-541]
-	_ = x[evTransformation-542]
-	_ = x[evTransformationEnd-543]
-	_ = x[evUpdateTrustlevel-544]
-	_ = x[evRevealHiddenTimeStamps-545]
-	_ = x[evModifyItemTraitFinished-546]
-	_ = x[evRerollItemTraitValueFinished-547]
-	_ = x[evHuntQuestProgressInfo-548]
-	_ = x[evHuntStarted-549]
-	_ = x[evHuntFinished-550]
-	_ = x[evHuntAborted-551]
-	_ = x[evHuntMissionStepStateUpdate-552]
-	_ = x[evNewHuntTrack-553]
-	_ = x[evHuntMissionUpdate-554]
-	_ = x[evHuntQuestMissionProgressUpdate-555]
-	_ = x[evHuntTrackUsed-556]
-	_ = x[evHuntTrackUseableAgain-557]
-	_ = x[evMinimapHuntTrackMarkers-558]
-	_ = x[evNoTracksFound-559]
-	_ = x[evHuntQuestAborted-560]
-	_ = x[evInteractWithTrackStart-561]
-	_ = x[evInteractWithTrackCancel-562]
-	_ = x[evInteractWithTrackFinished-563]
-	_ = x[evNewDynamicCompound-564]
-	_ = x[evLegendaryItemDestroyed-565]
-	_ = x[evAttunementInfo-566]
-	_ = x[evTerritoryClaimRaidedRawEnergyCrystalResult-567]
-	_ = x[evCarriedObjectExpiryWarning-568]
-	_ = x[evCarriedObjectExpired-569]
-	_ = x[evTerritoryRaidStart-570]
-	_ = x[evTerritoryRaidCancel-571]
-	_ = x[evTerritoryRaidFinished-572]
-	_ = x[evTerritoryRaidResult-573]
-	_ = x[evTerritoryMonolithActiveRaidStatus-574]
-	_ = x[evTerritoryMonolithActiveRaidCancelled-575]
-	_ = x[evMonolithEnergyStorageUpdate-576]
-	_ = x[evMonolithNextScheduledOpenWorldAttackUpdate-577]
-	_ = x[evMonolithProtectedBuildingsDamageReductionUpdate-578]
-	_ = x[evNewBuildingBaseEvent-579]
-	_ = x[evNewFortificationBuilding-580]
-	_ = x[evNewCastleGateBuilding-581]
-	_ = x[evBuildingDurabilityUpdate-582]
-	_ = x[evMonolithFortificationPointsUpdate-583]
-	_ = x[evFortificationBuildingUpgradeInfo-584]
-	_ = x[evFortificationBuildingsDamageStateUpdate-585]
-	_ = x[evSiegeNotificationEvent-586]
-	_ = x[evUpdateEnemyWarBannerActive-587]
-	_ = x[evTerritoryAnnouncePlayerEjection-588]
-	_ = x[evCastleGateSwitchUseStarted-589]
-	_ = x[evCastleGateSwitchUseFinished-590]
-	_ = x[evFortificationBuildingWillDowngrade-591]
-	_ = x[evBotCommand-592]
-	_ = x[evJournalAchievementProgressUpdate-593]
-	_ = x[evJournalClaimableRewardUpdate-594]
-	_ = x[evKeySync-595]
-	_ = x[evLocalQuestAreaGone-596]
-	_ = x[evDynamicTemplate-597]
-	_ = x[evDynamicTemplateForcedStateChange-598]
-	_ = x[evNewOutlandsTeleportationPortal-599]
-	_ = x[evNewOutlandsTeleportationReturnPortal-600]
-	_ = x[evOutlandsTeleportationBindingCleared-601]
-	_ = x[evOutlandsTeleportationReturnPortalUpdateEvent-602]
-	_ = x[evPlayerUsedOutlandsTeleportationPortal-603]
-	_ = x[evEncumberedRestricted-604]
-	_ = x[evNewPiledObject-605]
-	_ = x[evPiledObjectStateChanged-606]
-	_ = x[evNewSmugglerCrateDeliveryStation-607]
-	_ = x[evKillRewardedNoFame-608]
-	_ = x[evPickupFromPiledObjectStart-609]
-	_ = x[evPickupFromPiledObjectCancel-610]
-	_ = x[evPickupFromPiledObjectReset-611]
-	_ = x[evPickupFromPiledObjectFinished-612]
-	_ = x[evArmoryActivityChange-613]
-	_ = x[evNewKillTrophyFurnitureBuilding-614]
-	_ = x[evHellDungeonsPlayerJoinedInfo-615]
-	_ = x[evNewTileSwitchTrigger-616]
-	_ = x[evNewMultiRewardObject-617]
-	_ = x[evNewHellDungeonSoulShrineObject-618]
-	_ = x[evHellDungeonSoulShrineStateUpdate-619]
-	_ = x[evNewResurrectionShrine-620]
-	_ = x[evUpdateResurrectionShrine-621]
-	_ = x[evStandTimeFinished-622]
-	_ = x[evEpicAchievementAndStatsUpdate-623]
-	_ = x[evSpectateTargetAfterDeathUpdate-624]
-	_ = x[evSpectateTargetAfterDeathEnded-625]
-	_ = x[evNewHellDungeonUpwardExit-626]
-	_ = x[evNewHellDungeonSoulExit-627]
-	_ = x[evNewHellDungeonDownwardExit-628]
-	_ = x[evNewHellDungeonChestExit-629]
-	_ = x[evNewCorruptedStaticEntrance-630]
-	_ = x[evNewHellDungeonStaticEntrance-631]
-	_ = x[evUpdateHellDungeonStaticEntranceState-632]
-	_ = x[evDebugTriggerHellDungeonShutdownStart-633]
-	_ = x[evFullJournalQuestInfo-634]
-	_ = x[evJournalQuestProgressInfo-635]
-	_ = x[evNewHellDungeonRoomShrineObject-636]
-	_ = x[evHellDungeonRoomShrineStateUpdate-637]
-	_ = x[evSimpleBehaviourBuildingStateUpdate-638]
-	_ = x[evSetTimeScaling-639]
-	_ = x[evStopTimeScaling-640]
-	_ = x[evKeyValidation-641]
-	_ = x[evPlayerJoinMapMarkerTimerStates-642]
-	_ = x[evNewMapMarkerTimer-643]
-	_ = x[evRemoveMapMarkerTimer-644]
-	_ = x[evNewFactionFortressObject-645]
-	_ = x[evFactionFortressAnnouncePlayerEjection-646]
-	_ = x[evRewardFactionWarfareSupply-647]
-	_ = x[evFactionCaptureAreaProgressUpdate-648]
-	_ = x[evFactionFortressClaimed-649]
-	_ = x[evFactionFortressWeaponCachesSpawned-650]
-	_ = x[evFactionFortressWeaponCacheClaimed-651]
-	_ = x[evFactionFortressFightStateUpdate-652]
-	_ = x[evFactionFortressCutoffFightStateUpdate-653]
-	_ = x[evFactionFortressFightEnded-654]
-	_ = x[evNewFactionWarfarePortal-655]
-	_ = x[evFactionPortalTargetUpdate-656]
-	_ = x[evFactionFortressFightStartedInRemoteClusterEvent-657]
-	_ = x[evFactionFortressFightFinishedInRemoteClusterEvent-658]
-	_ = x[evFactionDuchySupplyWarDefensiveVictoryEvent-659]
-	_ = x[evFactionDuchyReconnectedFromCutoffEvent-660]
-	_ = x[evFactionFortressCutoffFightCancelledByClusterOwnerChangeEvent-661]
-}
-
-const _EventType_name = "evUnusedevLeaveevJoinFinishedevMoveevTeleportevChangeEquipmentevHealthUpdateevHealthUpdatesevEnergyUpdateevDamageShieldUpdateevCraftingFocusUpdateevActiveSpellEffectsUpdateevResetCooldownsevAttackevCastStartevChannelingUpdateevCastCancelevCastTimeUpdateevCastFinishedevCastSpellevCastSpellsevCastHitevCastHitsevStoredTargetsUpdateevChannelingEndedevAttackBuildingevInventoryPutItemevInventoryDeleteItemevInventoryStateevNewCharacterevNewEquipmentItemevNewSiegeBannerItemevNewSimpleItemevNewFurnitureItemevNewKillTrophyItemevNewJournalItemevNewLaborerItemevNewEquipmentItemLegendarySoulevNewSimpleHarvestableObjectevNewSimpleHarvestableObjectListevNewHarvestableObjectevNewTreasureDestinationObjectevTreasureDestinationObjectStatusevCloseTreasureDestinationObjectevNewSilverObjectevNewBuildingevHarvestableChangeStateevMobChangeStateevFactionBuildingInfoevCraftBuildingInfoevRepairBuildingInfoevMeldBuildingInfoevConstructionSiteInfoevPlayerBuildingInfoevFarmBuildingInfoevTutorialBuildingInfoevLaborerObjectInfoevLaborerObjectJobInfoevMarketPlaceBuildingInfoevHarvestStartevHarvestCancelevHarvestFinishedevTakeSilverevRemoveSilverevActionOnBuildingStartevActionOnBuildingCancelevActionOnBuildingFinishedevItemRerollQualityFinishedevInstallResourceStartevInstallResourceCancelevInstallResourceFinishedevCraftItemFinishedevLogoutCancelevChatMessageevChatSayevChatWhisperevChatMutedevPlayEmoteevStopEmoteevSystemMessageevUtilityTextMessageevUpdateMoneyevUpdateFameevUpdateLearningPointsevUpdateReSpecPointsevUpdateCurrencyevUpdateFactionStandingevUpdateStandingevRespawnevServerDebugLogevCharacterEquipmentChangedevRegenerationHealthChangedevRegenerationEnergyChangedevRegenerationMountHealthChangedevRegenerationCraftingChangedevRegenerationHealthEnergyComboChangedevRegenerationPlayerComboChangedevDurabilityChangedevNewLootevAttachItemContainerevDetachItemContainerevInvalidateItemContainerevLockItemContainerevGuildUpdateevGuildPlayerUpdatedevInvitedToGuildevGuildMemberWorldUpdateevUpdateMatchDetailsevObjectEventevNewMonolithObjectevMonolithHasBannersPlacedUpdateevNewOrbObjectevNewCastleObjectevNewSpellEffectAreaevUpdateSpellEffectAreaevNewChainSpellevUpdateChainSpellevNewTreasureChestevStartMatchevStartArenaMatchInfosevEndArenaMatchevMatchUpdateevActiveMatchUpdateevNewMobevDebugMobInfoevDebugVariablesInfoevDebugReputationInfoevDebugDiminishingReturnInfoevDebugSmartClusterQueueInfoevClaimOrbStartevClaimOrbFinishedevClaimOrbCancelevOrbUpdateevOrbClaimedevOrbResetevNewWarCampObjectevNewMatchLootChestObjectevNewArenaExitevGuildMemberTerritoryUpdateevInvitedMercenaryToMatchevClusterInfoUpdateevForcedMovementevForcedMovementCancelevCharacterStatsevCharacterStatsKillHistoryevCharacterStatsDeathHistoryevCharacterStatsKnockDownHistoryevCharacterStatsKnockedDownHistoryevGuildStatsevKillHistoryDetailsevItemKillHistoryDetailsevFullAchievementInfoevFinishedAchievementevAchievementProgressInfoevFullAchievementProgressInfoevFullTrackedAchievementInfoevFullAutoLearnAchievementInfoevQuestGiverQuestOfferedevQuestGiverDebugInfoevConsoleEventevTimeSyncevChangeAvatarevChangeMountSkinevGameEventevKilledPlayerevDiedevKnockedDownevUnconciousevMatchPlayerJoinedEventevMatchPlayerStatsEventevMatchPlayerStatsCompleteEventevMatchTimeLineEventEventevMatchPlayerMainGearStatsEventevMatchPlayerChangedAvatarEventevInvitationPlayerTradeevPlayerTradeStartevPlayerTradeCancelevPlayerTradeUpdateevPlayerTradeFinishedevPlayerTradeAcceptChangeevMiniMapPingevMarketPlaceNotificationevDuellingChallengePlayerevNewDuellingPostevDuelStartedevDuelEndedevDuelDeniedevDuelRequestCanceledevDuelLeftAreaevDuelReEnteredAreaevNewRealEstateevMiniMapOwnedBuildingsPositionsevRealEstateListUpdateevGuildLogoUpdateevGuildLogoChangedevPlaceableObjectPlaceevPlaceableObjectPlaceCancelevFurnitureObjectBuffProviderInfoevFurnitureObjectCheatProviderInfoevFarmableObjectInfoevNewUnreadMailsevMailOperationPossibleevGuildLogoObjectUpdateevStartLogoutevNewChatChannelsevJoinedChatChannelevLeftChatChannelevRemovedChatChannelevAccessStatusevMountedevMountStartevMountCancelevNewTravelpointevNewIslandAccessPointevNewExitevUpdateHomeevUpdateChatSettingsevResurrectionOfferevResurrectionReplyevLootEquipmentChangedevUpdateUnlockedGuildLogosevUpdateUnlockedAvatarsevUpdateUnlockedAvatarRingsevUpdateUnlockedBuildingsevNewIslandManagementevNewTeleportStoneevCloakevPartyInvitationevPartyJoinRequestevPartyJoinedevPartyDisbandedevPartyPlayerJoinedevPartyChangedOrderevPartyPlayerLeftevPartyLeaderChangedevPartyLootSettingChangedPlayerevPartySilverGainedevPartyPlayerUpdatedevPartyInvitationAnswerevPartyJoinRequestAnswerevPartyMarkedObjectsUpdatedevPartyOnClusterPartyJoinedevPartySetRoleFlagevPartyInviteOrJoinPlayerEquipmentInfoevPartyReadyCheckUpdateevPartyFactionWarfareReinforcementSettingChangedPlayerevSpellCooldownUpdateevNewHellgateExitPortalevNewExpeditionExitevNewExpeditionNarratorevExitEnterStartevExitEnterCancelevExitEnterFinishedevNewQuestGiverObjectevFullQuestInfoevQuestProgressInfoevQuestGiverInfoForPlayerevFullExpeditionInfoevExpeditionQuestProgressInfoevInvitedToExpeditionevExpeditionRegistrationInfoevEnteringExpeditionStartevEnteringExpeditionCancelevRewardGrantedevArenaRegistrationInfoevEnteringArenaStartevEnteringArenaCancelevEnteringArenaLockStartevEnteringArenaLockCancelevInvitedToArenaMatchevUsingHellgateShrineevEnteringHellgateLockStartevEnteringHellgateLockCancelevPlayerCountsevInCombatStateUpdateevOtherGrabbedLootevTreasureChestUsingStartevTreasureChestUsingFinishedevTreasureChestUsingCancelevTreasureChestUsingOpeningCompleteevTreasureChestForceCloseInventoryevLocalTreasuresUpdateevLootChestSpawnpointsUpdateevPremiumChangedevPremiumExtendedevPremiumLifeTimeRewardGainedevGoldPurchasedevLaborerGotUpgradedevJournalGotFullevJournalFillErrorevFriendRequestevFriendRequestInfosevFriendInfosevFriendRequestAnsweredevFriendOnlineStatusevFriendRequestCanceledevFriendRemovedevFriendUpdatedevPartyLootItemsevPartyLootItemsRemovedevPartyLootItemTypesRemovedevReputationUpdateevDefenseUnitAttackBeginevDefenseUnitAttackEndevDefenseUnitAttackDamageevUnrestrictedPvpZoneUpdateevUnrestrictedPvpZoneStatusevReputationImplicationUpdateevNewMountObjectevMountHealthUpdateevMountCooldownUpdateevNewExpeditionAgentevNewExpeditionCheckPointevExpeditionStartEventevVoteEventevRatingEventevNewArenaAgentevBoostFarmableevUseFunctionevNewPortalEntranceevNewPortalExitevNewRandomDungeonExitevWaitingQueueUpdateevPlayerMovementRateUpdateevObserveStartevMinimapZergsevMinimapSmartClusterZergsevPaymentTransactionsevPerformanceStatsUpdateevOverloadModeUpdateevDebugDrawEventevRecordCameraMoveevRecordStartevDeliverCarriableObjectStartevDeliverCarriableObjectCancelevDeliverCarriableObjectResetevDeliverCarriableObjectFinishedevTerritoryClaimStartevTerritoryClaimCancelevTerritoryClaimFinishedevTerritoryScheduleResultevTerritoryUpgradeWithPowerCrystalResultevReceiveCarriableObjectStartevReceiveCarriableObjectFinishedevUpdateAccountStateevStartDeterministicRoamevGuildFullAccessTagsUpdatedevGuildAccessTagUpdatedevGvgSeasonUpdateevGvgSeasonCheatCommandevSeasonPointsByKillingBoosterevFishingStartevFishingCastevFishingCatchevFishingFinishedevFishingCancelevNewFloatObjectevNewFishingZoneObjectevFishingMiniGameevAlbionJournalAchievementCompletedevUpdatePuppetevChangeFlaggingFinishedevNewOutpostObjectevOutpostUpdateevOutpostClaimedevOverChargeEndevOverChargeStatusevPartyFinderFullUpdateevPartyFinderUpdateevPartyFinderApplicantsUpdateevPartyFinderEquipmentSnapshotevPartyFinderJoinRequestDeclinedevNewUnlockedPersonalSeasonRewardsevPersonalSeasonPointsGainedevPersonalSeasonPastSeasonDataEventevMatchLootChestOpeningStartevMatchLootChestOpeningFinishedevMatchLootChestOpeningCancelevNotifyCrystalMatchRewardevCrystalRealmFeedbackevNewLocationMarkerevNewTutorialBlockerevNewTileSwitchevNewInformationProviderevNewDynamicGuildLogoevNewDecorationevTutorialUpdateevTriggerHintBoxevRandomDungeonPositionInfoevNewLootChestevUpdateLootChestevLootChestOpenedevUpdateLootProtectedByMobsWithMinimapDisplayevNewShrineevUpdateShrineevUpdateRoomevNewMobSoulevNewHellgateShrineevUpdateHellgateShrineevActivateHellgateExitevMutePlayerUpdateevShopTileUpdateevShopUpdateevAntiCheatKickevBattlEyeServerMessageevUnlockVanityUnlockevAvatarUnlockedevCustomizationChangedevBaseVaultInfoevGuildVaultInfoevBankVaultInfoevRecoveryVaultPlayerInfoevRecoveryVaultGuildInfoevUpdateWardrobeevCastlePhaseChangedevGuildAccountLogEventevNewHideoutObjectevNewHideoutManagementevNewHideoutExitevInitHideoutAttackStartevInitHideoutAttackCancelevInitHideoutAttackFinishedevHideoutManagementUpdateevHideoutUpgradeWithPowerCrystalResultevIpChangedevSmartClusterQueueUpdateInfoevSmartClusterQueueActiveInfoevSmartClusterQueueKickWarningevSmartClusterQueueInviteevReceivedGvgSeasonPointsevTowerPowerPointUpdateevOpenWorldAttackScheduleStartevOpenWorldAttackScheduleFinishedevOpenWorldAttackScheduleCancelevOpenWorldAttackConquerStartevOpenWorldAttackConquerFinishedevOpenWorldAttackConquerCancelevOpenWorldAttackConquerStatusevOpenWorldAttackStartevOpenWorldAttackEndevNewRandomResourceBlockerevNewHomeObjectevHideoutObjectUpdateevUpdateInfamyevMinimapPositionMarkersevNewTunnelExitevCorruptedDungeonUpdateevCorruptedDungeonStatusevCorruptedDungeonInfamyevHellgateRestrictedAreaUpdateevHellgateInfamyevHellgateStatusevHellgateStatusUpdateevHellgateSuspenseevReplaceSpellSlotWithMultiSpellevNewCorruptedShrineevUpdateCorruptedShrineevCorruptedShrineUsageStartevCorruptedShrineUsageCancelevExitUsedevLinkedToObjectevLinkToObjectBrokenevEstimatedMarketValueUpdateevStuckCancelevDungonEscapeReadyevFactionWarfareClusterStateevFactionWarfareHasUnclaimedWeeklyReportsEventevSimpleFeedbackevSmartClusterQueueSkipClusterErrorevXignCodeEventevBatchUseItemStartevBatchUseItemEndevRedZoneEventClusterStatusevRedZonePlayerNotificationevRedZoneWorldEventevFactionWarfareStatsevUpdateFactionBalanceFactorsevFactionEnlistmentChangedevUpdateFactionRankevFactionWarfareCampaignRewardsUnlockedevFeaturedFeatureUpdateevNewCarriableObjectevMinimapCrystalPositionMarkerevCarriedObjectUpdateevPickupCarriableObjectStartevPickupCarriableObjectCancelevPickupCarriableObjectFinishedevDoSimpleActionStartevDoSimpleActionCancelevDoSimpleActionFinishedevNotifyGuestAccountVerifiedevMightAndFavorReceivedEventevWeeklyPvpChallengeRewardStateUpdateevNewUnlockedPvpSeasonChallengeRewardsevStaticDungeonEntrancesDungeonEventStatusUpdatesevStaticDungeonDungeonValueUpdateevStaticDungeonEntranceDungeonEventsAbortedevInAppPurchaseConfirmedGooglePlayevFeatureSwitchInfoevPartyJoinRequestAbortedevPartyInviteAbortedevPartyStartHuntRequestevPartyStartHuntRequestedevPartyStartHuntRequestAnswerevPartyPlayerLeaveScheduledevGuildInviteDeclinedevCancelMultiSpellSlotsevNewVisualEventObjectevCastleClaimProgressevCastleClaimProgressLogoevTownPortalUpdateStateevTownPortalFailedevConsumableVanityChargesAddedevFestivitiesUpdateevNewBannerObjectevNewMistsImmediateReturnExitevMistsPlayerJoinedInfoevNewMistsStaticEntranceevNewMistsOpenWorldExitevNewTunnelExitTempevNewMistsWispSpawnevMistsWispSpawnStateChangeevNewMistsCityEntranceevNewMistsCityRoadsEntranceevMistsCityRoadsEntrancePartyStateUpdateevMistsCityRoadsEntranceClearStateForPartyevMistsEntranceDataChangedevNewCagedObjectevCagedObjectStateUpdatedevEntrancePartyBindingCreatedevEntrancePartyBindingClearedevEntrancePartyBindingInfosevNewMistsBorderExitevNewMistsDungeonExitevLocalQuestInfosevLocalQuestStartedevLocalQuestActiveevLocalQuestInactiveevLocalQuestProgressUpdateevNewUnrestrictedPvpZoneevTemporaryFlaggingStatusUpdateevSpellTestPerformanceUpdateevTransformationevTransformationEndevUpdateTrustlevelevRevealHiddenTimeStampsevModifyItemTraitFinishedevRerollItemTraitValueFinishedevHuntQuestProgressInfoevHuntStartedevHuntFinishedevHuntAbortedevHuntMissionStepStateUpdateevNewHuntTrackevHuntMissionUpdateevHuntQuestMissionProgressUpdateevHuntTrackUsedevHuntTrackUseableAgainevMinimapHuntTrackMarkersevNoTracksFoundevHuntQuestAbortedevInteractWithTrackStartevInteractWithTrackCancelevInteractWithTrackFinishedevNewDynamicCompoundevLegendaryItemDestroyedevAttunementInfoevTerritoryClaimRaidedRawEnergyCrystalResultevCarriedObjectExpiryWarningevCarriedObjectExpiredevTerritoryRaidStartevTerritoryRaidCancelevTerritoryRaidFinishedevTerritoryRaidResultevTerritoryMonolithActiveRaidStatusevTerritoryMonolithActiveRaidCancelledevMonolithEnergyStorageUpdateevMonolithNextScheduledOpenWorldAttackUpdateevMonolithProtectedBuildingsDamageReductionUpdateevNewBuildingBaseEventevNewFortificationBuildingevNewCastleGateBuildingevBuildingDurabilityUpdateevMonolithFortificationPointsUpdateevFortificationBuildingUpgradeInfoevFortificationBuildingsDamageStateUpdateevSiegeNotificationEventevUpdateEnemyWarBannerActiveevTerritoryAnnouncePlayerEjectionevCastleGateSwitchUseStartedevCastleGateSwitchUseFinishedevFortificationBuildingWillDowngradeevBotCommandevJournalAchievementProgressUpdateevJournalClaimableRewardUpdateevKeySyncevLocalQuestAreaGoneevDynamicTemplateevDynamicTemplateForcedStateChangeevNewOutlandsTeleportationPortalevNewOutlandsTeleportationReturnPortalevOutlandsTeleportationBindingClearedevOutlandsTeleportationReturnPortalUpdateEventevPlayerUsedOutlandsTeleportationPortalevEncumberedRestrictedevNewPiledObjectevPiledObjectStateChangedevNewSmugglerCrateDeliveryStationevKillRewardedNoFameevPickupFromPiledObjectStartevPickupFromPiledObjectCancelevPickupFromPiledObjectResetevPickupFromPiledObjectFinishedevArmoryActivityChangeevNewKillTrophyFurnitureBuildingevHellDungeonsPlayerJoinedInfoevNewTileSwitchTriggerevNewMultiRewardObjectevNewHellDungeonSoulShrineObjectevHellDungeonSoulShrineStateUpdateevNewResurrectionShrineevUpdateResurrectionShrineevStandTimeFinishedevEpicAchievementAndStatsUpdateevSpectateTargetAfterDeathUpdateevSpectateTargetAfterDeathEndedevNewHellDungeonUpwardExitevNewHellDungeonSoulExitevNewHellDungeonDownwardExitevNewHellDungeonChestExitevNewCorruptedStaticEntranceevNewHellDungeonStaticEntranceevUpdateHellDungeonStaticEntranceStateevDebugTriggerHellDungeonShutdownStartevFullJournalQuestInfoevJournalQuestProgressInfoevNewHellDungeonRoomShrineObjectevHellDungeonRoomShrineStateUpdateevSimpleBehaviourBuildingStateUpdateevSetTimeScalingevStopTimeScalingevKeyValidationevPlayerJoinMapMarkerTimerStatesevNewMapMarkerTimerevRemoveMapMarkerTimerevNewFactionFortressObjectevFactionFortressAnnouncePlayerEjectionevRewardFactionWarfareSupplyevFactionCaptureAreaProgressUpdateevFactionFortressClaimedevFactionFortressWeaponCachesSpawnedevFactionFortressWeaponCacheClaimedevFactionFortressFightStateUpdateevFactionFortressCutoffFightStateUpdateevFactionFortressFightEndedevNewFactionWarfarePortalevFactionPortalTargetUpdateevFactionFortressFightStartedInRemoteClusterEventevFactionFortressFightFinishedInRemoteClusterEventevFactionDuchySupplyWarDefensiveVictoryEventevFactionDuchyReconnectedFromCutoffEventevFactionFortressCutoffFightCancelledByClusterOwnerChangeEvent"
-
-var _EventType_index = [...]uint16{0, 8, 15, 29, 35, 45, 62, 76, 91, 105, 125, 146, 172, 188, 196, 207, 225, 237, 253, 267, 278, 290, 299, 309, 330, 347, 363, 381, 402, 418, 432, 450, 470, 485, 503, 522, 538, 554, 585, 613, 645, 667, 697, 730, 762, 779, 792, 816, 832, 853, 872, 892, 910, 932, 952, 970, 992, 1011, 1033, 1058, 1072, 1087, 1104, 1116, 1130, 1153, 1177, 1203, 1230, 1252, 1275, 1300, 1319, 1333, 1346, 1355, 1368, 1379, 1390, 1401, 1416, 1436, 1449, 1461, 1483, 1503, 1519, 1542, 1558, 1567, 1583, 1610, 1637, 1664, 1696, 1725, 1763, 1795, 1814, 1823, 1844, 1865, 1890, 1909, 1922, 1942, 1958, 1982, 2002, 2015, 2034, 2066, 2080, 2097, 2117, 2140, 2155, 2173, 2191, 2203, 2225, 2240, 2253, 2272, 2280, 2294, 2314, 2335, 2363, 2391, 2406, 2424, 2440, 2451, 2463, 2473, 2491, 2516, 2530, 2558, 2583, 2602, 2618, 2640, 2656, 2683, 2711, 2743, 2777, 2789, 2809, 2833, 2854, 2875, 2900, 2929, 2957, 2987, 3011, 3032, 3046, 3056, 3070, 3087, 3098, 3112, 3118, 3131, 3143, 3167, 3190, 3221, 3246, 3277, 3308, 3331, 3349, 3368, 3387, 3408, 3433, 3446, 3471, 3496, 3513, 3526, 3537, 3549, 3570, 3584, 3603, 3618, 3650, 3672, 3689, 3707, 3729, 3757, 3790, 3824, 3844, 3860, 3883, 3906, 3919, 3936, 3955, 3972, 3992, 4006, 4015, 4027, 4040, 4056, 4078, 4087, 4099, 4119, 4138, 4157, 4179, 4205, 4228, 4255, 4280, 4301, 4319, 4326, 4343, 4361, 4374, 4390, 4409, 4428, 4445, 4465, 4496, 4515, 4535, 4558, 4582, 4609, 4636, 4654, 4692, 4715, 4769, 4790, 4813, 4832, 4855, 4871, 4888, 4907, 4928, 4943, 4962, 4987, 5007, 5036, 5057, 5085, 5110, 5136, 5151, 5174, 5194, 5215, 5239, 5264, 5285, 5306, 5333, 5361, 5375, 5396, 5414, 5439, 5467, 5493, 5528, 5562, 5584, 5612, 5628, 5645, 5674, 5689, 5709, 5725, 5743, 5758, 5778, 5791, 5814, 5834, 5857, 5872, 5887, 5903, 5926, 5953, 5971, 5995, 6017, 6042, 6069, 6096, 6125, 6141, 6160, 6181, 6201, 6226, 6248, 6259, 6272, 6287, 6302, 6315, 6334, 6349, 6371, 6391, 6417, 6431, 6445, 6471, 6492, 6516, 6536, 6552, 6570, 6583, 6612, 6642, 6671, 6703, 6724, 6746, 6770, 6795, 6835, 6864, 6896, 6916, 6940, 6968, 6991, 7008, 7031, 7061, 7075, 7088, 7102, 7119, 7134, 7150, 7172, 7189, 7224, 7238, 7262, 7280, 7295, 7311, 7326, 7344, 7367, 7386, 7415, 7445, 7477, 7511, 7539, 7574, 7602, 7633, 7662, 7688, 7710, 7729, 7749, 7764, 7788, 7809, 7824, 7840, 7856, 7883, 7897, 7914, 7931, 7976, 7987, 8001, 8013, 8025, 8044, 8066, 8088, 8106, 8122, 8134, 8149, 8172, 8192, 8208, 8230, 8245, 8261, 8276, 8301, 8325, 8341, 8361, 8383, 8401, 8423, 8439, 8463, 8488, 8515, 8540, 8578, 8589, 8618, 8647, 8677, 8702, 8727, 8750, 8780, 8813, 8844, 8873, 8905, 8935, 8965, 8987, 9007, 9033, 9048, 9069, 9083, 9107, 9122, 9146, 9170, 9194, 9224, 9240, 9256, 9278, 9296, 9328, 9348, 9371, 9398, 9426, 9436, 9452, 9472, 9500, 9513, 9532, 9560, 9606, 9622, 9657, 9672, 9691, 9708, 9735, 9762, 9781, 9802, 9831, 9857, 9876, 9915, 9938, 9958, 9988, 10009, 10037, 10066, 10097, 10118, 10140, 10164, 10192, 10220, 10257, 10295, 10344, 10377, 10420, 10454, 10473, 10498, 10518, 10541, 10566, 10595, 10622, 10643, 10666, 10688, 10709, 10734, 10757, 10775, 10805, 10824, 10841, 10870, 10893, 10917, 10940, 10959, 10978, 11005, 11027, 11054, 11094, 11136, 11162, 11178, 11203, 11232, 11261, 11288, 11308, 11329, 11346, 11365, 11383, 11403, 11429, 11453, 11484, 11512, 11528, 11547, 11565, 11589, 11614, 11644, 11667, 11680, 11694, 11707, 11735, 11749, 11768, 11800, 11815, 11838, 11863, 11878, 11896, 11920, 11945, 11972, 11992, 12016, 12032, 12076, 12104, 12126, 12146, 12167, 12190, 12211, 12246, 12284, 12313, 12357, 12406, 12428, 12454, 12477, 12503, 12538, 12572, 12613, 12637, 12665, 12698, 12726, 12755, 12791, 12803, 12837, 12867, 12876, 12896, 12913, 12947, 12979, 13017, 13054, 13100, 13139, 13161, 13177, 13202, 13235, 13255, 13283, 13312, 13340, 13371, 13393, 13425, 13455, 13477, 13499, 13531, 13565, 13588, 13614, 13633, 13664, 13696, 13727, 13753, 13777, 13805, 13830, 13858, 13888, 13926, 13964, 13986, 14012, 14044, 14078, 14114, 14130, 14147, 14162, 14194, 14213, 14235, 14261, 14300, 14328, 14362, 14386, 14422, 14457, 14490, 14529, 14556, 14581, 14608, 14657, 14707, 14751, 14791, 14853}
-
-func (i EventType) String() string {
-	if i >= EventType(len(_EventType_index)-1) {
-		return "EventType(" + strconv.FormatInt(int64(i), 10) + ")"
-	}
-	return _EventType_name[_EventType_index[i]:_EventType_index[i+1]]
-}
@@ -0,0 +1,236 @@
+package client
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"testing"
+
+	photon "github.com/ao-data/photon-spectator"
+)
+
+// paramFixture is a message in testdata/, see testdata/README.md
+type paramFixture struct {
+	Source string                                `json:"source"`
+	Kind   string                                `json:"kind"`
+	Name   string                                `json:"name"`
+	Params map[string]map[string]json.RawMessage `json:"params"`
+}
+
+// loadFixture reads a fixture into the params photon would have decoded,
+// with the message code of its name in the embedded code table
+func loadFixture(t testing.TB, path string) (messageKind, map[uint8]interface{}) {
+	t.Helper()
+
+	data, err := os.ReadFile(filepath.Join("testdata", path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fixture paramFixture
+	if err := json.Unmarshal(data, &fixture); err != nil {
+		t.Fatalf("%v: %v", path, err)
+	}
+
+	params := make(map[uint8]interface{})
+	for key, typed := range fixture.Params {
+		k, err := strconv.ParseUint(key, 10, 8)
+		if err != nil {
+			t.Fatalf("%v: param key %q: %v", path, key, err)
+		}
+		if len(typed) != 1 {
+			t.Fatalf("%v: param %v must have exactly one type", path, key)
+		}
+		for typ, raw := range typed {
+			if params[uint8(k)], err = fixtureValue(typ, raw); err != nil {
+				t.Fatalf("%v: param %v: %v", path, key, err)
+			}
+		}
+	}
+
+	var kind messageKind
+	var code int16
+	var found bool
+	switch fixture.Kind {
+	case "event":
+		kind = kindEvent
+		var ev EventType
+		ev, found = codes.event(fixture.Name)
+		code = int16(ev)
+	case "request", "response":
+		kind = kindRequest
+		if fixture.Kind == "response" {
+			kind = kindResponse
+		}
+		var op OperationType
+		op, found = codes.operation(fixture.Name)
+		code = int16(op)
+	default:
+		t.Fatalf("%v: unknown kind %q", path, fixture.Kind)
+	}
+	if !found {
+		t.Fatalf("%v: the code table has no %v named %v", path, fixture.Kind, fixture.Name)
+	}
+	params[codeParam(kind)] = code
+	return kind, params
+}
+
+// fixtureValue converts a JSON value to the Go type photon decodes it to
+func fixtureValue(typ string, raw json.RawMessage) (interface{}, error) {
+	switch typ {
+	case "int8":
+		return unmarshalAs[int8](raw)
+	case "int16":
+		return unmarshalAs[int16](raw)
+	case "int32":
+		return unmarshalAs[int32](raw)
+	case "int64":
+		return unmarshalAs[int64](raw)
+	case "float32":
+		return unmarshalAs[float32](raw)
+	case "bool":
+		return unmarshalAs[bool](raw)
+	case "string":
+		return unmarshalAs[string](raw)
+	case "[]int8":
+		return unmarshalAs[[]int8](raw)
+	case "[]int16":
+		return unmarshalAs[[]int16](raw)
+	case "[]int32":
+		return unmarshalAs[[]int32](raw)
+	case "[]int64":
+		return unmarshalAs[[]int64](raw)
+	case "[]float32":
+		return unmarshalAs[[]float32](raw)
+	case "[]bool":
+		return unmarshalAs[[]bool](raw)
+	case "[]string":
+		return unmarshalAs[[]string](raw)
+	}
+	return nil, fmt.Errorf("unknown type %q", typ)
+}
+
+func unmarshalAs[T any](raw json.RawMessage) (interface{}, error) {
+	var v T
+	err := json.Unmarshal(raw, &v)
+	return v, err
+}
+
+// decodeFixture decodes a fixture through the registered handlers and
+// returns the one of type T
+func decodeFixture[T operation](t testing.TB, path string) T {
+	t.Helper()
+
+	kind, params := loadFixture(t, path)
+	operations, err := decodeMessage(kind, params)
+	if err != nil {
+		t.Fatalf("%v: %v", path, err)
+	}
+	for _, op := range operations {
+		if decoded, ok := op.(T); ok {
+			return decoded
+		}
+	}
+	var zero T
+	t.Fatalf("%v: no %T handler decoded it, got %d operations", path, zero, len(operations))
+	return zero
+}
+
+// fixtureCommand encodes params into the reliable command photon would have
+// captured them in
+func fixtureCommand(t testing.TB, kind messageKind, params map[uint8]interface{}) photon.PhotonCommand {
+	t.Helper()
+
+	code, _ := params[codeParam(kind)].(int16)
+	var msg bytes.Buffer
+	msg.WriteByte(0xf3)
+	switch kind {
+	case kindRequest:
+		msg.Write([]byte{photon.OperationRequest, uint8(code)})
+	case kindResponse:
+		// a return code of 0 and no debug message
+		msg.Write([]byte{photon.OperationResponse, uint8(code), 0, 0, photon.NilType})
+	case kindEvent:
+		msg.Write([]byte{photon.EventDataType, uint8(code)})
+	}
+	binary.Write(&msg, binary.BigEndian, int16(len(params)))
+
+	keys := make([]int, 0, len(params))
+	for key := range params {
+		keys = append(keys, int(key))
+	}
+	sort.Ints(keys)
+	for _, key := range keys {
+		msg.WriteByte(uint8(key))
+		if err := encodeParam(&msg, params[uint8(key)], true); err != nil {
+			t.Fatalf("param %v: %v", key, err)
+		}
+	}
+
+	return photon.PhotonCommand{
+		Type:   photon.SendReliableType,
+		Length: int32(msg.Len() + 12),
+		Data:   msg.Bytes(),
+	}
+}
+
+// encodeParam writes a value the way photon's decoder reads it, with its
+// type code unless it is an element of a slice
+func encodeParam(buf *bytes.Buffer, value interface{}, typed bool) error {
+	typeCode := func(code uint8) {
+		if typed {
+			buf.WriteByte(code)
+		}
+	}
+	slice := func(length int, code uint8) {
+		typeCode(photon.SliceType)
+		binary.Write(buf, binary.BigEndian, uint16(length))
+		buf.WriteByte(code)
+	}
+
+	switch v := value.(type) {
+	case int8:
+		typeCode(photon.Int8Type)
+	case int16:
+		typeCode(photon.Int16Type)
+	case int32:
+		typeCode(photon.Int32Type)
+	case int64:
+		typeCode(photon.Int64Type)
+	case float32:
+		typeCode(photon.Float32Type)
+	case bool:
+		typeCode(photon.BooleanType)
+	case string:
+		typeCode(photon.StringType)
+		binary.Write(buf, binary.BigEndian, uint16(len(v)))
+		buf.WriteString(v)
+		return nil
+	case []int8:
+		typeCode(photon.Int8SliceType)
+		binary.Write(buf, binary.BigEndian, uint32(len(v)))
+	case []int16:
+		slice(len(v), photon.Int16Type)
+	case []int32:
+		slice(len(v), photon.Int32Type)
+	case []int64:
+		slice(len(v), photon.Int64Type)
+	case []float32:
+		slice(len(v), photon.Float32Type)
+	case []bool:
+		slice(len(v), photon.BooleanType)
+	case []string:
+		slice(len(v), photon.StringType)
+		for _, s := range v {
+			encodeParam(buf, s, false)
+		}
+		return nil
+	default:
+		return fmt.Errorf("cannot encode %T", value)
+	}
+	return binary.Write(buf, binary.BigEndian, value)
+}
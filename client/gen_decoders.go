@@ -0,0 +1,195 @@
+//go:build ignore
+
+// gen_decoders writes decode_generated.go. For every operation and event
+// struct in this package it emits a decode method that fills the fields from
+// the photon params by their mapstructure tags, without reflection. A field
+// tagged mapstructure:",remain" gets all params, for handlers that look for a
+// value whose position is not known.
+//
+// Run it with go generate after adding or changing a handler struct.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"log"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const output = "decode_generated.go"
+
+var numbers = map[string]bool{
+	"int": true, "int8": true, "int16": true, "int32": true, "int64": true,
+	"uint": true, "uint8": true, "uint16": true, "uint32": true, "uint64": true,
+	"float32": true, "float64": true,
+	// named numbers from other packages
+	"lib.Timescale": true,
+}
+
+type field struct {
+	name   string
+	key    int
+	typ    string
+	remain bool
+}
+
+type handler struct {
+	name   string
+	fields []field
+}
+
+func main() {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, ".", func(info os.FileInfo) bool {
+		name := info.Name()
+		return !strings.HasSuffix(name, "_test.go") && name != output && name != "gen_decoders.go"
+	}, 0)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var handlers []handler
+	for _, file := range pkgs["client"].Files {
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				typeSpec := spec.(*ast.TypeSpec)
+				name := typeSpec.Name.Name
+				if !strings.HasPrefix(name, "operation") && !strings.HasPrefix(name, "event") {
+					continue
+				}
+				structType, ok := typeSpec.Type.(*ast.StructType)
+				if !ok {
+					continue
+				}
+				h, err := parseHandler(name, structType)
+				if err != nil {
+					log.Fatalf("%v: %v", name, err)
+				}
+				handlers = append(handlers, h)
+			}
+		}
+	}
+
+	sort.Slice(handlers, func(i, j int) bool { return handlers[i].name < handlers[j].name })
+
+	var body bytes.Buffer
+	usesLib := false
+
+	for _, h := range handlers {
+		fmt.Fprintf(&body, "\nfunc (op *%s) decode(params map[uint8]interface{}) (err error) {\n", h.name)
+		for _, f := range h.fields {
+			if f.remain {
+				fmt.Fprintf(&body, "\top.%s = params\n", f.name)
+				continue
+			}
+			call, err := decodeCall(f.typ)
+			if err != nil {
+				log.Fatalf("%v.%v: %v", h.name, f.name, err)
+			}
+			usesLib = usesLib || strings.Contains(call, "lib.")
+			fmt.Fprintf(&body, "\tif v := params[%d]; v != nil {\n", f.key)
+			fmt.Fprintf(&body, "\t\tif op.%s, err = %s(v); err != nil {\n", f.name, call)
+			fmt.Fprintf(&body, "\t\t\treturn paramError(%d, %q, err)\n", f.key, f.name)
+			body.WriteString("\t\t}\n\t}\n")
+		}
+		body.WriteString("\treturn nil\n}\n")
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by gen_decoders.go; DO NOT EDIT.\n\n")
+	buf.WriteString("package client\n")
+	if usesLib {
+		buf.WriteString("\nimport \"github.com/ao-data/albiondata-client/lib\"\n")
+	}
+	buf.Write(body.Bytes())
+
+	source, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatalf("generated invalid code: %v\n%s", err, buf.Bytes())
+	}
+
+	if err := os.WriteFile(output, source, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func parseHandler(name string, structType *ast.StructType) (handler, error) {
+	h := handler{name: name}
+
+	for _, f := range structType.Fields.List {
+		if f.Tag == nil || len(f.Names) != 1 {
+			continue
+		}
+		tag, err := strconv.Unquote(f.Tag.Value)
+		if err != nil {
+			return h, err
+		}
+		name := reflect.StructTag(tag).Get("mapstructure")
+		if name == ",remain" {
+			if typ := typeString(f.Type); typ != "map[uint8]interface{}" {
+				return h, fmt.Errorf("remain field %v must be map[uint8]interface{}, not %v", f.Names[0].Name, typ)
+			}
+			h.fields = append(h.fields, field{name: f.Names[0].Name, remain: true})
+			continue
+		}
+		key, err := strconv.Atoi(name)
+		if err != nil {
+			continue
+		}
+		if key < 0 || key > 255 {
+			return h, fmt.Errorf("param key %d out of range", key)
+		}
+		h.fields = append(h.fields, field{name: f.Names[0].Name, key: key, typ: typeString(f.Type)})
+	}
+
+	return h, nil
+}
+
+func typeString(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		return t.Name
+	case *ast.SelectorExpr:
+		return typeString(t.X) + "." + t.Sel.Name
+	case *ast.ArrayType:
+		return "[]" + typeString(t.Elt)
+	case *ast.MapType:
+		return "map[" + typeString(t.Key) + "]" + typeString(t.Value)
+	case *ast.InterfaceType:
+		return "interface{}"
+	}
+	return fmt.Sprintf("%T", expr)
+}
+
+// decodeCall returns the helper from decode.go that converts to typ
+func decodeCall(typ string) (string, error) {
+	switch {
+	case typ == "bool":
+		return "decodeBool", nil
+	case typ == "string":
+		return "decodeString", nil
+	case typ == "lib.CharacterID":
+		return "decodeCharacterIDParam", nil
+	case numbers[typ]:
+		return "decodeNumber[" + typ + "]", nil
+	case typ == "[]string":
+		return "decodeStrings", nil
+	case strings.HasPrefix(typ, "[][]") && numbers[typ[4:]]:
+		return "decodeNumberSlices[" + typ[4:] + "]", nil
+	case strings.HasPrefix(typ, "[]") && numbers[typ[2:]]:
+		return "decodeNumbers[" + typ[2:] + "]", nil
+	}
+	return "", fmt.Errorf("no decoder for type %v", typ)
+}
@@ -19,14 +19,16 @@ type listener struct {
 	sourcePackets chan gopacket.Packet
 	commands      chan photon.PhotonCommand
 	displayName   string
-	fragments     *photon.FragmentBuffer
-	quit          chan bool
-	router        *Router
+	// fragments are reassembled per game session, two game clients number
+	// theirs independently
+	fragments map[string]*photon.FragmentBuffer
+	quit      chan bool
+	router    *Router
 }
 
 func newListener(router *Router) *listener {
 	return &listener{
-		fragments: photon.NewFragmentBuffer(),
+		fragments: make(map[string]*photon.FragmentBuffer),
 		commands:  make(chan photon.PhotonCommand, 1),
 		quit:      make(chan bool, 1),
 		router:    router,
@@ -92,14 +94,16 @@ func (l *listener) startOfflineCommandGob(path string) {
 			}
 			err = decoder.Decode(command)
 			if err != nil {
-				if err == io.EOF {
-					break
+				if err != io.EOF {
+					// the stream cannot be resumed after an error
+					log.Error("Could not decode command ", err)
 				}
-				log.Error("Could not decode command ", err)
-				continue
+				break
 			}
 			l.commands <- *command
 		}
+		// the listener stops once it handled them all
+		close(l.commands)
 
 		err = file.Close()
 		if err != nil {
@@ -134,8 +138,12 @@ func (l *listener) run() {
 				l.handle.Close()
 				return
 			}
-		case command := <-l.commands:
-			l.onReliableCommand(&command)
+		case command, ok := <-l.commands:
+			if !ok {
+				// MUST only happen with the offline processor.
+				return
+			}
+			l.onReliableCommand(&command, l.router.sessions.get(""))
 		}
 	}
 }
@@ -163,10 +171,15 @@ func (l *listener) processPacket(packet gopacket.Packet) {
 		log.Trace("No IPv4 detected")
 		return
 	}
-	l.router.albionstate.GameServerIP = ipv4.SrcIP.String()
-	l.router.albionstate.AODataServerID, l.router.albionstate.AODataIngestBaseURL = l.router.albionstate.GetServer()
-	log.Tracef("Server ID: %s", l.router.albionstate.AODataServerID)
-	log.Tracef("Using AODataIngestBaseURL: %s", l.router.albionstate.AODataIngestBaseURL)
+	session := packetSession(packet)
+	state := l.router.sessions.get(session)
+	// what we send carries our own address
+	if fromGameServer(packet) {
+		state.setGameServer(ipv4.SrcIP.String())
+	}
+	snapshot := state.snapshot()
+	log.Tracef("Server ID: %d", snapshot.AODataServerID)
+	log.Tracef("Using AODataIngestBaseURL: %s", snapshot.AODataIngestBaseURL)
 
 	layer := packet.Layer(photon.PhotonLayerType)
 
@@ -179,25 +192,34 @@ func (l *listener) processPacket(packet gopacket.Packet) {
 	for _, command := range content.Commands {
 		switch command.Type {
 		case photon.SendReliableType:
-			l.onReliableCommand(&command)
+			l.onReliableCommand(&command, state)
 		case photon.SendUnreliableType:
 			var s = make([]byte, len(command.Data)-4)
 			copy(s, command.Data[4:])
 			command.Data = s
 			command.Length -= 4
 			command.Type = 6
-			l.onReliableCommand(&command)
+			l.onReliableCommand(&command, state)
 		case photon.SendReliableFragmentType:
 			msg, _ := command.ReliableFragment()
-			result := l.fragments.Offer(msg)
+			result := l.fragmentBuffer(session).Offer(msg)
 			if result != nil {
-				l.onReliableCommand(result)
+				l.onReliableCommand(result, state)
 			}
 		}
 	}
 }
 
-func (l *listener) onReliableCommand(command *photon.PhotonCommand) {
+func (l *listener) fragmentBuffer(session string) *photon.FragmentBuffer {
+	fragments, ok := l.fragments[session]
+	if !ok {
+		fragments = photon.NewFragmentBuffer()
+		l.fragments[session] = fragments
+	}
+	return fragments
+}
+
+func (l *listener) onReliableCommand(command *photon.PhotonCommand, state *albionState) {
 	// Record all photon commands even if the params did not parse correctly
 	if ConfigGlobal.RecordPath != "" {
 		l.router.recordPhotonCommand <- *command
@@ -206,8 +228,8 @@ func (l *listener) onReliableCommand(command *photon.PhotonCommand) {
 	msg, err := command.ReliableMessage()
 	if err != nil {
 
-		if fmt.Sprint(err) == "Encryption not supported" && l.router.albionstate.WaitingForMarketData == true {
-			l.router.albionstate.WaitingForMarketData = false
+		if fmt.Sprint(err) == "Encryption not supported" && state.snapshot().WaitingForMarketData {
+			state.setWaitingForMarketData(false)
 			log.Info("Market data is encrypted. Please see https://www.albion-online-data.com/client/encryption.html for more information.")
 		}
 
@@ -224,11 +246,11 @@ func (l *listener) onReliableCommand(command *photon.PhotonCommand) {
 		return
 	}
 
-	var operation operation
+	var operations []operation
 
 	switch msg.Type {
 	case photon.OperationRequest:
-		operation, err = decodeRequest(params)
+		operations, err = decodeMessage(kindRequest, params)
 		if params[253] != nil {
 			number := params[253].(int16)
 			shouldDebug, exists := ConfigGlobal.DebugOperations[int(number)]
@@ -239,7 +261,7 @@ func (l *listener) onReliableCommand(command *photon.PhotonCommand) {
 			log.Debugf("OperationRequest: ERROR - %v", params)
 		}
 	case photon.OperationResponse:
-		operation, err = decodeResponse(params)
+		operations, err = decodeMessage(kindResponse, params)
 		if params[253] != nil {
 			number := params[253].(int16)
 			shouldDebug, exists := ConfigGlobal.DebugOperations[int(number)]
@@ -250,7 +272,7 @@ func (l *listener) onReliableCommand(command *photon.PhotonCommand) {
 			log.Debugf("OperationResponse: ERROR - %v", params)
 		}
 	case photon.EventDataType:
-		operation, err = decodeEvent(params)
+		operations, err = decodeMessage(kindEvent, params)
 		if params[252] != nil {
 			number := params[252].(int16)
 			shouldDebug, exists := ConfigGlobal.DebugEvents[int(number)]
@@ -266,10 +288,10 @@ func (l *listener) onReliableCommand(command *photon.PhotonCommand) {
 
 	if err != nil && !ConfigGlobal.DebugIgnoreDecodingErrors {
 		log.Debugf("Error while decoding an event or operation: %v - params: %v", err, params)
-		operation = nil
+		operations = nil
 	}
 
-	if operation != nil {
-		l.router.newOperation <- operation
+	for _, operation := range operations {
+		l.router.enqueue(operation, state)
 	}
 }
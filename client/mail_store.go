@@ -0,0 +1,113 @@
+package client
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Upper bound of cached mail infos, a mailbox never holds that many
+const mailStoreLimit = 2000
+
+// mailStore caches the mail infos by mail ID until the mails expire. Mail IDs
+// are unique across characters and sessions, so the cache outlives both.
+type mailStore struct {
+	mu    sync.Mutex
+	mails map[int]MailInfo
+	limit int
+
+	// Called with all mails after every change, for persistence
+	onChange func([]MailInfo)
+}
+
+func newMailStore(limit int) *mailStore {
+	return &mailStore{
+		mails: make(map[int]MailInfo),
+		limit: limit,
+	}
+}
+
+// mailInfos holds the mail infos of every character we have seen
+var mailInfos = newMailStore(mailStoreLimit)
+
+// get returns the info of a mail, or nil if we never saw it
+func (s *mailStore) get(id int) *MailInfo {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	mail, ok := s.mails[id]
+	if !ok {
+		return nil
+	}
+	return &mail
+}
+
+// add stores mail infos, replacing what we knew about the same mails
+func (s *mailStore) add(mails []MailInfo) {
+	s.mu.Lock()
+	for _, mail := range mails {
+		s.mails[mail.ID] = mail
+	}
+	s.evict(time.Now())
+	all := s.all()
+	s.mu.Unlock()
+
+	s.changed(all)
+}
+
+// restore loads mails saved by a previous run, without saving them again
+func (s *mailStore) restore(mails []MailInfo) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, mail := range mails {
+		s.mails[mail.ID] = mail
+	}
+	s.evict(time.Now())
+}
+
+func (s *mailStore) len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.mails)
+}
+
+func (s *mailStore) changed(all []MailInfo) {
+	if s.onChange != nil {
+		s.onChange(all)
+	}
+}
+
+// evict drops expired mails, then the ones expiring first while there are too
+// many. Must be called with the mutex held.
+func (s *mailStore) evict(now time.Time) {
+	for id, mail := range s.mails {
+		if mail.Expires > 0 && mail.expiresAt().Before(now) {
+			delete(s.mails, id)
+		}
+	}
+
+	if len(s.mails) <= s.limit {
+		return
+	}
+
+	all := s.all()
+	for _, mail := range all[:len(all)-s.limit] {
+		delete(s.mails, mail.ID)
+	}
+}
+
+// all returns the mails sorted by expiry. Must be called with the mutex held.
+func (s *mailStore) all() []MailInfo {
+	all := make([]MailInfo, 0, len(s.mails))
+	for _, mail := range s.mails {
+		all = append(all, mail)
+	}
+	sort.Slice(all, func(i, j int) bool {
+		if a, b := all[i].expiresAt(), all[j].expiresAt(); !a.Equal(b) {
+			return a.Before(b)
+		}
+		return all[i].ID < all[j].ID
+	})
+	return all
+}
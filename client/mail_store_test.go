@@ -0,0 +1,84 @@
+package client
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+// toTicks converts a time to .NET ticks, as the game sends them
+func toTicks(t time.Time) int64 {
+	return t.UnixNano()/100 + 621355968000000000
+}
+
+func TestMailInfosFixture(t *testing.T) {
+	op := decodeFixture[*operationGetMailInfosResponse](t, "operations/GetMailInfos.response.json")
+
+	if want := []int{301245, 301246}; !reflect.DeepEqual(op.MailIDs, want) {
+		t.Errorf("MailIDs = %v, want %v", op.MailIDs, want)
+	}
+	if want := []string{"3005", "0007"}; !reflect.DeepEqual(op.Locations, want) {
+		t.Errorf("Locations = %v, want %v", op.Locations, want)
+	}
+	if len(op.OrderTypes) != 2 || op.OrderTypes[0] != "MARKETPLACE_BUYORDER_FINISHED_SUMMARY" {
+		t.Errorf("OrderTypes = %v", op.OrderTypes)
+	}
+
+	mail := MailInfo{Expires: op.Expires[0]}
+	if want := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC); !mail.expiresAt().Equal(want) {
+		t.Errorf("Expires %d reads as %v, want %v", op.Expires[0], mail.expiresAt(), want)
+	}
+}
+
+func TestDeleteMailFixture(t *testing.T) {
+	op := decodeFixture[*operationDeleteMail](t, "operations/DeleteMail.request.json")
+
+	if want := []int{301245, 301246}; !reflect.DeepEqual(op.MailIDs, want) {
+		t.Errorf("MailIDs = %v, want %v", op.MailIDs, want)
+	}
+
+	saved := mailInfos
+	defer func() { mailInfos = saved }()
+	mailInfos = newMailStore(10)
+	mailInfos.add([]MailInfo{{ID: 301245, Expires: toTicks(time.Now().Add(time.Hour))}})
+
+	op.Process(nil)
+	if mailInfos.get(301245) == nil {
+		t.Error("DeleteMail dropped a mail, its layout is not confirmed")
+	}
+}
+
+func TestMailStoreEvictsByTicksAndSeconds(t *testing.T) {
+	now := time.Now()
+	store := newMailStore(10)
+	store.add([]MailInfo{
+		{ID: 1, Expires: toTicks(now.Add(-time.Hour))},
+		{ID: 2, Expires: toTicks(now.Add(time.Hour))},
+		{ID: 3, Expires: now.Add(-time.Hour).Unix()},
+		{ID: 4, Expires: now.Add(time.Hour).Unix()},
+		{ID: 5},
+	})
+
+	for id, kept := range map[int]bool{1: false, 2: true, 3: false, 4: true, 5: true} {
+		if got := store.get(id) != nil; got != kept {
+			t.Errorf("mail %d kept = %v, want %v", id, got, kept)
+		}
+	}
+}
+
+func TestMailStoreLimitDropsFirstExpiring(t *testing.T) {
+	now := time.Now()
+	store := newMailStore(2)
+	store.add([]MailInfo{
+		{ID: 1, Expires: toTicks(now.Add(3 * time.Hour))},
+		{ID: 2, Expires: now.Add(time.Hour).Unix()},
+		{ID: 3, Expires: toTicks(now.Add(2 * time.Hour))},
+	})
+
+	if store.get(2) != nil {
+		t.Error("the mail expiring first was kept")
+	}
+	if store.get(1) == nil || store.get(3) == nil {
+		t.Error("a mail expiring later was dropped")
+	}
+}
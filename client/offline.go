@@ -3,15 +3,23 @@ package client
 import (
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/ao-data/albiondata-client/log"
 )
 
+// How long the uploads of an offline run may take to go out before exiting
+const offlineUploadWait = 30 * time.Second
+
+// processOffline replays a capture. It returns once every operation in it was
+// handled, but the slow handlers and uploads may still be running, see
+// closeDispatcher.
 func processOffline(path string) {
 	log.Infof("Beginning offline process with %v", path)
 
 	r := newRouter()
 	go r.run()
+	defer r.stop()
 
 	_, err := os.Stat(path)
 
@@ -31,4 +39,6 @@ func processOffline(path string) {
 	} else {
 		log.Error("Only .pcap and .gob files supported at this time.")
 	}
+
+	logUnhandledCodes()
 }
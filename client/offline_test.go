@@ -0,0 +1,94 @@
+package client
+
+import (
+	"encoding/gob"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ao-data/albiondata-client/lib"
+	"github.com/ao-data/albiondata-client/mockingest"
+)
+
+// fixtureMessage is a decoded message of a replay
+type fixtureMessage struct {
+	kind   messageKind
+	params map[uint8]interface{}
+}
+
+// writeCommandGob records the messages as a -record file
+func writeCommandGob(t *testing.T, path string, messages []fixtureMessage) {
+	t.Helper()
+
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	encoder := gob.NewEncoder(file)
+	for _, m := range messages {
+		if err := encoder.Encode(fixtureCommand(t, m.kind, m.params)); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// The uploads of the last messages of a replay, made by slow handlers on the
+// worker pool, must still go out before the run exits
+func TestProcessOfflineUploadsEverything(t *testing.T) {
+	server := mockingest.NewServer(0)
+	ingest := httptest.NewServer(server)
+	defer ingest.Close()
+
+	saved := *ConfigGlobal
+	defer func() {
+		*ConfigGlobal = saved
+		dis = nil
+	}()
+	ConfigGlobal.PublicIngestBaseUrls = ingest.URL
+	ConfigGlobal.PrivateIngestBaseUrls = ""
+	ConfigGlobal.DisableUpload = false
+	ConfigGlobal.EnableWebsockets = false
+	ConfigGlobal.RecordPath = ""
+	ConfigGlobal.QueuePath = ""
+	ConfigGlobal.UploadMaxAge = time.Hour
+	ConfigGlobal.MaxPayloadSize = 0
+	ConfigGlobal.Debug = true
+
+	const rounds = 20
+	kind, join := loadFixture(t, "operations/Join.response.json")
+	messages := []fixtureMessage{{kind, join}}
+	for i := 0; i < rounds; i++ {
+		kind, params := loadFixture(t, "operations/GoldMarketGetAverageInfo.response.json")
+		messages = append(messages, fixtureMessage{kind, params})
+
+		kind, request := loadFixture(t, "operations/AuctionGetItemAverageStats.request.json")
+		request[255] = int32(1000 + i)
+		kind2, response := loadFixture(t, "operations/AuctionGetItemAverageStats.response.json")
+		response[255] = int32(1000 + i)
+		messages = append(messages, fixtureMessage{kind, request}, fixtureMessage{kind2, response})
+	}
+	path := filepath.Join(t.TempDir(), "replay.gob")
+	writeCommandGob(t, path, messages)
+
+	createDispatcher()
+	processOffline(path)
+	closeDispatcher(10 * time.Second)
+
+	// everything was sent when closeDispatcher returned
+	if got := len(server.Received(lib.NatsGoldPricesIngest)); got != rounds {
+		t.Errorf("received %d gold prices, want %d", got, rounds)
+	}
+	if got := len(server.Received(lib.NatsMarketHistoriesIngest)); got != rounds {
+		t.Errorf("received %d market histories, want %d", got, rounds)
+	}
+	if _, ok := server.WaitFor("", 2*rounds+1, 100*time.Millisecond); ok {
+		t.Errorf("received more than the %d uploads replayed", 2*rounds)
+	}
+	if server.Rejected() != 0 {
+		t.Errorf("%d uploads were rejected", server.Rejected())
+	}
+}
@@ -2,13 +2,17 @@ package client
 
 import (
 	"sort"
-	"time"
 
 	"github.com/ao-data/albiondata-client/lib"
 	"github.com/ao-data/albiondata-client/log"
 	uuid "github.com/nu7hatch/gouuid"
 )
 
+func init() {
+	registerRequest("AuctionGetItemAverageStats", func() operation { return &operationAuctionGetItemAverageStats{} })
+	registerResponse("AuctionGetItemAverageStats", func() operation { return &operationAuctionGetItemAverageStatsResponse{} })
+}
+
 type operationAuctionGetItemAverageStats struct {
 	ItemID      int32         `mapstructure:"1"`
 	Quality     uint8         `mapstructure:"2"`
@@ -18,8 +22,6 @@ type operationAuctionGetItemAverageStats struct {
 }
 
 func (op operationAuctionGetItemAverageStats) Process(state *albionState) {
-	var index = op.MessageID % CacheSize
-
 	// It seems all items with id 129-256 come through as a negative integer. Example, goose eggs
 	// comes through as -121. (-121)+256=135. As of today (2024-01-07), the itemId in the ao-bin-dumps repo
 	// is 135. This occurs for all items we can search the market for with english text from id 128-256.
@@ -37,45 +39,37 @@ func (op operationAuctionGetItemAverageStats) Process(state *albionState) {
 		quality:   op.Quality,
 	}
 
-	state.marketHistoryIDLookup[index] = mhInfo
-	log.Debugf("Market History - Caching %d at %d.", mhInfo.albionId, index)
+	state.marketHistoryRequests.put(op.MessageID, mhInfo)
+	log.Debugf("Market History - Caching %d for message %d.", mhInfo.albionId, op.MessageID)
 }
 
 type operationAuctionGetItemAverageStatsResponse struct {
 	ItemAmounts   []int64  `mapstructure:"0"`
 	SilverAmounts []uint64 `mapstructure:"1"`
 	Timestamps    []uint64 `mapstructure:"2"`
-	MessageID     int      `mapstructure:"255"`
+	MessageID     uint64   `mapstructure:"255"`
 }
 
-func (op operationAuctionGetItemAverageStatsResponse) Process(state *albionState) {
-	var index = op.MessageID % CacheSize
+// It waits for the request, so it must not hold up the ordered lane
+func (op operationAuctionGetItemAverageStatsResponse) slow() {}
 
+func (op operationAuctionGetItemAverageStatsResponse) Process(state *albionState) {
 	// Wait for the correlating Request if it has not yet been processed
-	waits := 0
-	for waits < 30 {
-		if state.marketHistoryIDLookup[index].albionId < 1 {
-			time.Sleep(1 * time.Second)
-			waits += 1
-		} else {
-			break
-		}
+	mhInfo, ok := state.marketHistoryRequests.take(op.MessageID, requestWait)
+	if !ok {
+		log.Warnf("Market History - No request found for message %d", op.MessageID)
+		return
 	}
-
-	// Still no correlating Request has been processed
-	if state.marketHistoryIDLookup[index].albionId < 1 {
-		log.Warnf("Market History - Market history at index %d is invalid. Has albionId: %s ", index, state.marketHistoryIDLookup[index].albionId)
+	if mhInfo.albionId < 1 {
+		log.Warnf("Market History - Market history for message %d is invalid. Has albionId: %d ", op.MessageID, mhInfo.albionId)
 		return
 	}
 
-	var mhInfo = state.marketHistoryIDLookup[index]
-
-	// Clear the index in the cache
-	state.marketHistoryIDLookup[index].albionId = 0
-	log.Debugf("Market History - Loaded itemID %d from cache at index %d", mhInfo.albionId, index)
+	log.Debugf("Market History - Loaded itemID %d from cache for message %d", mhInfo.albionId, op.MessageID)
 	log.Debug("Got response to GetItemAverageStats operation for the itemID[", mhInfo.albionId, "] of quality: ", mhInfo.quality, " and on the timescale: ", mhInfo.timescale)
 
-	if !state.IsValidLocation() {
+	snapshot := state.snapshot()
+	if !snapshot.IsValidLocation() {
 		return
 	}
 
@@ -114,13 +108,13 @@ func (op operationAuctionGetItemAverageStatsResponse) Process(state *albionState
 
 	upload := lib.MarketHistoriesUpload{
 		AlbionId:     mhInfo.albionId,
-		LocationId:   state.LocationId,
+		LocationId:   snapshot.Location.ID,
 		QualityLevel: mhInfo.quality,
 		Timescale:    mhInfo.timescale,
 		Histories:    histories,
 	}
 
 	identifier, _ := uuid.NewV4()
-	log.Infof("Sending %d market history item average stats to ingest for albionID %d (Identifier: %s)", len(histories), mhInfo.albionId, identifier)
+	log.Infof("Sending %d market history item average stats to ingest for albionID %d at %v (Identifier: %s)", len(histories), mhInfo.albionId, snapshot.Location, identifier)
 	sendMsgToPublicUploaders(upload, lib.NatsMarketHistoriesIngest, state, identifier.String())
 }
@@ -9,6 +9,11 @@ import (
 	uuid "github.com/nu7hatch/gouuid"
 )
 
+func init() {
+	registerRequest("AuctionGetOffers", func() operation { return &operationAuctionGetOffers{} })
+	registerResponse("AuctionGetOffers", func() operation { return &operationAuctionGetOffersResponse{} })
+}
+
 type operationAuctionGetOffers struct {
 	Category         string   `mapstructure:"1"`
 	SubCategory      string   `mapstructure:"2"`
@@ -18,22 +23,30 @@ type operationAuctionGetOffers struct {
 	ItemIds          []uint16 `mapstructure:"8"`
 	MaxResults       uint32   `mapstructure:"12"`
 	IsAscendingOrder bool     `mapstructure:"14"`
+	MessageID        uint64   `mapstructure:"255"`
 }
 
 func (op operationAuctionGetOffers) Process(state *albionState) {
 	log.Debug("Got AuctionGetOffers operation...")
-	state.WaitingForMarketData = true
+	state.setWaitingForMarketData(true)
+	state.auctionOffersRequests.put(op.MessageID, op)
 }
 
 type operationAuctionGetOffersResponse struct {
-	MarketOrders []string `mapstructure:"0"`
+	MarketOrders []string `mapstructure:"0" fingerprint:"json"`
+	MessageID    uint64   `mapstructure:"255"`
 }
 
 func (op operationAuctionGetOffersResponse) Process(state *albionState) {
 	log.Debug("Got response to AuctionGetOffers operation...")
-	state.WaitingForMarketData = false
+	state.setWaitingForMarketData(false)
+
+	// The request has been processed before in the ordered lane if we saw it.
+	// It is taken even if nothing is uploaded, so it does not wait for expiry.
+	request, asked := state.auctionOffersRequests.take(op.MessageID, 0)
 
-	if !state.IsValidLocation() {
+	snapshot := state.snapshot()
+	if !snapshot.IsValidLocation() {
 		return
 	}
 
@@ -73,7 +86,7 @@ func (op operationAuctionGetOffersResponse) Process(state *albionState) {
 		// Set the location only if its string(nil). Smugglers Dens pull locations directly from the market data (above)
 		// while the orignal cities have a null location ID and is pulled from the client state.
 		if order.LocationID == "" {
-			order.LocationID = state.LocationId
+			order.LocationID = snapshot.Location.ID
 		}
 
 		orders = append(orders, order)
@@ -83,11 +96,16 @@ func (op operationAuctionGetOffersResponse) Process(state *albionState) {
 		return
 	}
 
+	// The upload format has no place for the search, it is only logged
+	if asked {
+		log.Debugf("Market search for %v/%v items %v quality %v returned %d orders", request.Category, request.SubCategory, request.ItemIds, request.Quality, len(orders))
+	}
+
 	upload := lib.MarketUpload{
 		Orders: orders,
 	}
 
 	identifier, _ := uuid.NewV4()
-	log.Infof("Sending %d live market sell orders to ingest (Identifier: %s)", len(orders), identifier)
+	log.Infof("Sending %d live market sell orders from %v to ingest (Identifier: %s)", len(orders), snapshot.Location, identifier)
 	sendMsgToPublicUploaders(upload, lib.NatsMarketOrdersIngest, state, identifier.String())
 }
@@ -8,14 +8,20 @@ import (
 	uuid "github.com/nu7hatch/gouuid"
 )
 
+func init() {
+	registerResponse("AuctionGetRequests", func() operation { return &operationAuctionGetRequestsResponse{} })
+	registerResponse("AuctionBuyOffer", func() operation { return &operationAuctionGetRequestsResponse{} })
+}
+
 type operationAuctionGetRequestsResponse struct {
-	MarketOrders []string `mapstructure:"0"`
+	MarketOrders []string `mapstructure:"0" fingerprint:"json"`
 }
 
 func (op operationAuctionGetRequestsResponse) Process(state *albionState) {
 	log.Debug("Got response to AuctionGetOffers operation...")
 
-	if !state.IsValidLocation() {
+	snapshot := state.snapshot()
+	if !snapshot.IsValidLocation() {
 		return
 	}
 
@@ -29,7 +35,7 @@ func (op operationAuctionGetRequestsResponse) Process(state *albionState) {
 			log.Errorf("Problem converting market order to internal struct: %v", err)
 		}
 
-		order.LocationID = state.LocationId
+		order.LocationID = snapshot.Location.ID
 		orders = append(orders, order)
 	}
 
@@ -42,6 +48,6 @@ func (op operationAuctionGetRequestsResponse) Process(state *albionState) {
 	}
 
 	identifier, _ := uuid.NewV4()
-	log.Infof("Sending %d live market buy orders to ingest (Identifier: %s)", len(orders), identifier)
+	log.Infof("Sending %d live market buy orders from %v to ingest (Identifier: %s)", len(orders), snapshot.Location, identifier)
 	sendMsgToPublicUploaders(upload, lib.NatsMarketOrdersIngest, state, identifier.String())
 }
@@ -0,0 +1,26 @@
+package client
+
+import (
+	"github.com/ao-data/albiondata-client/log"
+)
+
+func init() {
+	registerRequest("DeleteMail", func() operation { return &operationDeleteMail{} })
+}
+
+type operationDeleteMail struct {
+	MailIDs []int `mapstructure:"0"`
+}
+
+func (op operationDeleteMail) Process(state *albionState) {
+	// the param layout is not confirmed by a capture, so the mails are not
+	// forgotten on its word, they are evicted once expired. The log tells
+	// whether the ids are ones we listed, to confirm it with.
+	known := 0
+	for _, id := range op.MailIDs {
+		if mailInfos.get(id) != nil {
+			known++
+		}
+	}
+	log.Debugf("Got DeleteMail operation for %d mails, %d of them known: %v", len(op.MailIDs), known, op.MailIDs)
+}
@@ -8,6 +8,11 @@ import (
 	uuid "github.com/nu7hatch/gouuid"
 )
 
+func init() {
+	registerRequest("GetClusterMapInfo", func() operation { return &operationGetClusterMapInfo{} })
+	registerResponse("GetClusterMapInfo", func() operation { return &operationGetClusterMapInfoResponse{} })
+}
+
 type operationGetClusterMapInfo struct {
 }
 
@@ -53,6 +58,6 @@ func (op operationGetClusterMapInfoResponse) Process(state *albionState) {
 	}
 
 	identifier, _ := uuid.NewV4()
-	log.Info("Sending map data to ingest (Identifier: %s)", identifier)
+	log.Infof("Sending map data to ingest (Identifier: %s)", identifier)
 	sendMsgToPublicUploaders(upload, lib.NatsMapDataIngest, state, identifier.String())
 }
@@ -1,29 +1,32 @@
 package client
 
 import (
-//	"strconv"
-//	"strings"
-
-//	"github.com/ao-data/albiondata-client/log"
+	"github.com/ao-data/albiondata-client/log"
 )
 
+func init() {
+	registerRequest("GetGameServerByCluster", func() operation { return &operationGetGameServerByCluster{} })
+	registerResponse("GetGameServerByCluster", func() operation { return &operationGetGameServerByClusterResponse{} })
+}
+
 type operationGetGameServerByCluster struct {
 	ZoneID string `mapstructure:"0"`
 }
 
 func (op operationGetGameServerByCluster) Process(state *albionState) {
-	// log.Debug("Got GetGameServerByCluster operation...")
+	log.Debugf("Got GetGameServerByCluster operation for %v...", op.ZoneID)
+}
+
+// operationGetGameServerByClusterResponse names the game server hosting the
+// cluster the player moves to
+type operationGetGameServerByClusterResponse struct {
+	Params map[uint8]interface{} `mapstructure:",remain"`
+}
 
-	// state.LocationString = op.ZoneID
-	// // TODO: Fix hack for second caerleon marketplace
-	// // Most likely will need to only use strings for player location in client
-	// zoneInt, err := strconv.Atoi(strings.Replace(op.ZoneID, "-Auction2", "", -1))
-	// if err != nil {
-	// 	log.Debugf("Unable to convert zoneID to int. Probably an instance.. ZoneID: %v", op.ZoneID)
-	// 	state.LocationId = -2 // hack
-	// 	return
-	// }
+func (op operationGetGameServerByClusterResponse) Process(state *albionState) {
+	log.Debug("Got response to GetGameServerByCluster operation...")
 
-	// log.Infof("Updating player location to %v.", zoneInt)
-	// state.LocationId = zoneInt
+	if address, ok := findServerAddress(op.Params); ok {
+		state.announceGameServer(address, "cluster change")
+	}
 }
@@ -4,22 +4,16 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/ao-data/albiondata-client/lib"
 	"github.com/ao-data/albiondata-client/log"
 )
 
-var MailInfos MailInfosLookup
-
-type MailInfosLookup []MailInfo
-
-func (mi MailInfosLookup) getMailInfo(id int) *MailInfo {
-	for i := range mi {
-		if mi[i].ID == id {
-			return &mi[i]
-		}
-	}
-	return nil
+func init() {
+	registerResponse("GetMailInfos", func() operation { return &operationGetMailInfosResponse{} })
 }
 
+// MailInfo keeps Expires as the game sent it, .NET ticks or unix seconds,
+// see lib.GameTime
 type MailInfo struct {
 	ID         int    `json:"MailId"`     // mapstructure:"3"
 	LocationID string `json:"LocationId"` // mapstructure:"6"
@@ -37,7 +31,11 @@ func (m *MailInfo) StringArray() []string {
 }
 
 func (m *MailInfo) StringExpires() string {
-	return time.Unix(m.Expires, 0).Format(time.RFC3339)
+	return m.expiresAt().Format(time.RFC3339)
+}
+
+func (m *MailInfo) expiresAt() time.Time {
+	return lib.GameTime(m.Expires)
 }
 
 type operationGetMailInfosResponse struct {
@@ -50,19 +48,27 @@ type operationGetMailInfosResponse struct {
 func (op operationGetMailInfosResponse) Process(state *albionState) {
 	log.Debugf("Got response to GetMailInfos operation")
 
-	for i := range op.MailIDs {
-		mail := &MailInfo{}
-		mail.ID = op.MailIDs[i]
-		mail.LocationID = op.Locations[i]
-		mail.OrderType = op.OrderTypes[i]
-		mail.Expires = op.Expires[i]
-		MailInfos = append(MailInfos, *mail)
+	count := len(op.MailIDs)
+	if len(op.Locations) != count || len(op.OrderTypes) != count || len(op.Expires) != count {
+		log.Debugf("Mail info arrays have different lengths (%d, %d, %d, %d), ignoring response", count, len(op.Locations), len(op.OrderTypes), len(op.Expires))
+		return
 	}
 
-	if len(MailInfos) < 1 {
+	if count < 1 {
 		log.Info("Mail Infos Response - no mails\n\n")
 		return
 	}
 
-	log.Infof("Mail Infos - Cached %#d mail infos", len(MailInfos))
+	mails := make([]MailInfo, count)
+	for i := range op.MailIDs {
+		mails[i] = MailInfo{
+			ID:         op.MailIDs[i],
+			LocationID: op.Locations[i],
+			OrderType:  op.OrderTypes[i],
+			Expires:    op.Expires[i],
+		}
+	}
+	mailInfos.add(mails)
+
+	log.Infof("Mail Infos - Cached %d mail infos", mailInfos.len())
 }
@@ -6,6 +6,11 @@ import (
 	uuid "github.com/nu7hatch/gouuid"
 )
 
+func init() {
+	registerRequest("GoldMarketGetAverageInfo", func() operation { return &operationGoldMarketGetAverageInfo{} })
+	registerResponse("GoldMarketGetAverageInfo", func() operation { return &operationGoldMarketGetAverageInfoResponse{} })
+}
+
 type operationGoldMarketGetAverageInfo struct {
 }
 
@@ -6,6 +6,10 @@ import (
 	"github.com/ao-data/albiondata-client/log"
 )
 
+func init() {
+	registerResponse("Join", func() operation { return &operationJoinResponse{} })
+}
+
 type operationJoinResponse struct {
 	CharacterID   lib.CharacterID `mapstructure:"1"`
 	CharacterName string          `mapstructure:"2"`
@@ -20,20 +24,22 @@ type operationJoinResponse struct {
 func (op operationJoinResponse) Process(state *albionState) {
 	log.Debugf("Got JoinResponse operation...")
 
-	// Reset the AODataServerID here. This leads to a fresh execution
-	// of SetServerID() incase the player switched servers
-	state.AODataServerID = 0
+	state.update(func(s *stateSnapshot) {
+		// Reset the AODataServerID here. This leads to a fresh execution
+		// of SetServerID() incase the player switched servers
+		s.AODataServerID = 0
 
-	log.Infof("Updating player location to %v.", op.Location)
-	state.LocationId = op.Location
+		s.Location = lib.ParseLocation(op.Location)
+		log.Infof("Updating player location to %v.", s.Location)
 
-	if state.CharacterId != op.CharacterID {
-		log.Infof("Updating player ID to %v.", op.CharacterID)
-	}
-	state.CharacterId = op.CharacterID
+		if s.CharacterId != op.CharacterID {
+			log.Infof("Updating player ID to %v.", op.CharacterID)
+		}
+		s.CharacterId = op.CharacterID
 
-	if state.CharacterName != op.CharacterName {
-		log.Infof("Updating player to %v.", op.CharacterName)
-	}
-	state.CharacterName = op.CharacterName
+		if s.CharacterName != op.CharacterName {
+			log.Infof("Updating player to %v.", op.CharacterName)
+		}
+		s.CharacterName = op.CharacterName
+	})
 }
@@ -0,0 +1,23 @@
+package client
+
+import (
+	"github.com/ao-data/albiondata-client/log"
+)
+
+func init() {
+	registerResponse("Login", func() operation { return &operationLoginResponse{} })
+}
+
+// operationLoginResponse comes from the login server and names the game
+// server the client connects to next
+type operationLoginResponse struct {
+	Params map[uint8]interface{} `mapstructure:",remain"`
+}
+
+func (op operationLoginResponse) Process(state *albionState) {
+	log.Debug("Got response to Login operation...")
+
+	if address, ok := findServerAddress(op.Params); ok {
+		state.announceGameServer(address, "login")
+	}
+}
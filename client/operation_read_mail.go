@@ -9,6 +9,10 @@ import (
 	uuid "github.com/nu7hatch/gouuid"
 )
 
+func init() {
+	registerResponse("ReadMail", func() operation { return &operationReadMail{} })
+}
+
 type operationReadMail struct {
 	ID   int    `mapstructure:"0"`
 	Body string `mapstructure:"1"`
@@ -21,7 +25,7 @@ func (op operationReadMail) Process(state *albionState) {
 	// split the mail body
 	body := strings.Split(op.Body, "|")
 
-	mailInfo := MailInfos.getMailInfo(op.ID)
+	mailInfo := mailInfos.get(op.ID)
 	if mailInfo == nil {
 		log.Info("Mail Info is not valid. Please transition zones or click at notification, so the mails can be loaded.")
 		return
@@ -70,7 +74,7 @@ func decodeSellNotification(op operationReadMail, body []string) lib.MarketNotif
 	notification.Price = price / 10000
 	notification.TotalAfterTaxes = float32(float32(notification.Price) * float32(notification.Amount) * (1.0 - lib.SalesTax))
 
-	mailInfo := MailInfos.getMailInfo(op.ID)
+	mailInfo := mailInfos.get(op.ID)
 	notification.LocationID = mailInfo.LocationID
 	notification.Expires = mailInfo.StringExpires()
 
@@ -104,7 +108,7 @@ func decodeExpiryNotification(op operationReadMail, body []string) lib.MarketNot
 	notification.Price = price / 10000
 	notification.Sold = sold
 
-	mailInfo := MailInfos.getMailInfo(op.ID)
+	mailInfo := mailInfos.get(op.ID)
 	notification.LocationID = mailInfo.LocationID
 	notification.Expires = mailInfo.StringExpires()
 
@@ -4,6 +4,11 @@ import (
 	"github.com/ao-data/albiondata-client/log"
 )
 
+func init() {
+	registerRequest("RealEstateBidOnAuction", func() operation { return &operationRealEstateBidOnAuction{} })
+	registerResponse("RealEstateBidOnAuction", func() operation { return &operationRealEstateBidOnAuctionResponse{} })
+}
+
 type operationRealEstateBidOnAuction struct {
 }
 
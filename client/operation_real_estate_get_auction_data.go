@@ -4,6 +4,11 @@ import (
 	"github.com/ao-data/albiondata-client/log"
 )
 
+func init() {
+	registerRequest("RealEstateGetAuctionData", func() operation { return &operationRealEstateGetAuctionData{} })
+	registerResponse("RealEstateGetAuctionData", func() operation { return &operationRealEstateGetAuctionDataResponse{} })
+}
+
 type operationRealEstateGetAuctionData struct {
 	PlotID int `mapstructure:"0"`
 }
@@ -1,555 +0,0 @@
-// Code generated by "stringer -type=OperationType"; DO NOT EDIT.
-
-package client
-
-import "strconv"
-
-func _() {
-	// An "invalid array index" compiler error signifies that the constant values have changed.
-	// Re-run the stringer command to generate them again.
-	var x [1]struct{}
-	_ = x[opUnused-0]
-	_ = x[opPing-1]
-	_ = x[opJoin-2]
-	_ = x[opVersionedOperation-3]
-	_ = x[opCreateAccount-4]
-	_ = x[opLogin-5]
-	_ = x[opCreateGuestAccount-6]
-	_ = x[opSendCrashLog-7]
-	_ = x[opSendTraceRoute-8]
-	_ = x[opSendVfxStats-9]
-	_ = x[opSendGamePingInfo-10]
-	_ = x[opCreateCharacter-11]
-	_ = x[opDeleteCharacter-12]
-	_ = x[opSelectCharacter-13]
-	_ = x[opAcceptPopups-14]
-	_ = x[opRedeemKeycode-15]
-	_ = x[opGetGameServerByCluster-16]
-	_ = x[opGetShopPurchaseUrl-17]
-	_ = x[opGetReferralSeasonDetails-18]
-	_ = x[opGetReferralLink-19]
-	_ = x[opGetShopTilesForCategory-20]
-	_ = x[opMove-21]
-	_ = x[opAttackStart-22]
-	_ = x[opCastStart-23]
-	_ = x[opCastCancel-24]
-	_ = x[opTerminateToggleSpell-25]
-	_ = x[opChannelingCancel-26]
-	_ = x[opAttackBuildingStart-27]
-	_ = x[opInventoryDestroyItem-28]
-	_ = x[opInventoryMoveItem-29]
-	_ = x[opInventoryRecoverItem-30]
-	_ = x[opInventoryRecoverAllItems-31]
-	_ = x[opInventorySplitStack-32]
-	_ = x[opInventorySplitStackInto-33]
-	_ = x[opGetClusterData-34]
-	_ = x[opChangeCluster-35]
-	_ = x[opConsoleCommand-36]
-	_ = x[opChatMessage-37]
-	_ = x[opReportClientError-38]
-	_ = x[opRegisterToObject-39]
-	_ = x[opUnRegisterFromObject-40]
-	_ = x[opCraftBuildingChangeSettings-41]
-	_ = x[opCraftBuildingTakeMoney-42]
-	_ = x[opRepairBuildingChangeSettings-43]
-	_ = x[opRepairBuildingTakeMoney-44]
-	_ = x[opActionBuildingChangeSettings-45]
-	_ = x[opHarvestStart-46]
-	_ = x[opHarvestCancel-47]
-	_ = x[opTakeSilver-48]
-	_ = x[opActionOnBuildingStart-49]
-	_ = x[opActionOnBuildingCancel-50]
-	_ = x[opInstallResourceStart-51]
-	_ = x[opInstallResourceCancel-52]
-	_ = x[opInstallSilver-53]
-	_ = x[opBuildingFillNutrition-54]
-	_ = x[opBuildingChangeRenovationState-55]
-	_ = x[opBuildingBuySkin-56]
-	_ = x[opBuildingClaim-57]
-	_ = x[opBuildingGiveup-58]
-	_ = x[opBuildingNutritionSilverStorageDeposit-59]
-	_ = x[opBuildingNutritionSilverStorageWithdraw-60]
-	_ = x[opBuildingNutritionSilverRewardSet-61]
-	_ = x[opConstructionSiteCreate-62]
-	_ = x[opPlaceableObjectPlace-63]
-	_ = x[opPlaceableObjectPlaceCancel-64]
-	_ = x[opPlaceableObjectPickup-65]
-	_ = x[opFurnitureObjectUse-66]
-	_ = x[opFarmableHarvest-67]
-	_ = x[opFarmableFinishGrownItem-68]
-	_ = x[opFarmableDestroy-69]
-	_ = x[opFarmableGetProduct-70]
-	_ = x[opFarmableFill-71]
-	_ = x[opTearDownConstructionSite-72]
-	_ = x[opAuctionCreateOffer-73]
-	_ = x[opAuctionCreateRequest-74]
-	_ = x[opAuctionGetOffers-75]
-	_ = x[opAuctionGetRequests-76]
-	_ = x[opAuctionBuyOffer-77]
-	_ = x[opAuctionAbortAuction-78]
-	_ = x[opAuctionModifyAuction-79]
-	_ = x[opAuctionAbortOffer-80]
-	_ = x[opAuctionAbortRequest-81]
-	_ = x[opAuctionSellRequest-82]
-	_ = x[opAuctionGetFinishedAuctions-83]
-	_ = x[opAuctionGetFinishedAuctionsCount-84]
-	_ = x[opAuctionFetchAuction-85]
-	_ = x[opAuctionGetMyOpenOffers-86]
-	_ = x[opAuctionGetMyOpenRequests-87]
-	_ = x[opAuctionGetMyOpenAuctions-88]
-	_ = x[opAuctionGetItemAverageStats-89]
-	_ = x[opAuctionGetItemAverageValue-90]
-	_ = x[opAuctionGetLowestOfferPrices-91]
-	_ = x[opContainerOpen-92]
-	_ = x[opContainerClose-93]
-	_ = x[opContainerManageSubContainer-94]
-	_ = x[opRespawn-95]
-	_ = x[opSuicide-96]
-	_ = x[opJoinGuild-97]
-	_ = x[opLeaveGuild-98]
-	_ = x[opCreateGuild-99]
-	_ = x[opInviteToGuild-100]
-	_ = x[opDeclineGuildInvitation-101]
-	_ = x[opKickFromGuild-102]
-	_ = x[opInstantJoinGuild-103]
-	_ = x[opDuellingChallengePlayer-104]
-	_ = x[opDuellingAcceptChallenge-105]
-	_ = x[opDuellingDenyChallenge-106]
-	_ = x[opChangeClusterTax-107]
-	_ = x[opClaimTerritory-108]
-	_ = x[opGiveUpTerritory-109]
-	_ = x[opChangeTerritoryAccessRights-110]
-	_ = x[opGetMonolithInfo-111]
-	_ = x[opGetClaimInfo-112]
-	_ = x[opGetAttackInfo-113]
-	_ = x[opGetTerritorySeasonPoints-114]
-	_ = x[opGetAttackSchedule-115]
-	_ = x[opGetMatches-116]
-	_ = x[opGetMatchDetails-117]
-	_ = x[opJoinMatch-118]
-	_ = x[opLeaveMatch-119]
-	_ = x[opGetClusterInstanceInfoForStaticCluster-120]
-	_ = x[opChangeChatSettings-121]
-	_ = x[opLogoutStart-122]
-	_ = x[opLogoutCancel-123]
-	_ = x[opClaimOrbStart-124]
-	_ = x[opClaimOrbCancel-125]
-	_ = x[opMatchLootChestOpeningStart-126]
-	_ = x[opMatchLootChestOpeningCancel-127]
-	_ = x[opDepositToGuildAccount-128]
-	_ = x[opWithdrawalFromAccount-129]
-	_ = x[opChangeGuildPayUpkeepFlag-130]
-	_ = x[opChangeGuildTax-131]
-	_ = x[opGetMyTerritories-132]
-	_ = x[opMorganaCommand-133]
-	_ = x[opGetServerInfo-134]
-	_ = x[opSubscribeToCluster-135]
-	_ = x[opAnswerMercenaryInvitation-136]
-	_ = x[opGetCharacterEquipment-137]
-	_ = x[opGetCharacterSteamAchievements-138]
-	_ = x[opGetCharacterStats-139]
-	_ = x[opGetKillHistoryDetails-140]
-	_ = x[opReSpecAchievement-141]
-	_ = x[opChangeAvatar-142]
-	_ = x[opGetRankings-143]
-	_ = x[opGetRank-144]
-	_ = x[opGetGvgSeasonRankings-145]
-	_ = x[opGetGvgSeasonRank-146]
-	_ = x[opGetGvgSeasonHistoryRankings-147]
-	_ = x[opGetGvgSeasonGuildMemberHistory-148]
-	_ = x[opKickFromGvGMatch-149]
-	_ = x[opGetCrystalLeagueDailySeasonPoints-150]
-	_ = x[opGetChestLogs-151]
-	_ = x[opGetAccessRightLogs-152]
-	_ = x[opGetGuildAccountLogs-153]
-	_ = x[opGetGuildAccountLogsLargeAmount-154]
-	_ = x[opInviteToPlayerTrade-155]
-	_ = x[opPlayerTradeCancel-156]
-	_ = x[opPlayerTradeInvitationAccept-157]
-	_ = x[opPlayerTradeAddItem-158]
-	_ = x[opPlayerTradeRemoveItem-159]
-	_ = x[opPlayerTradeAcceptTrade-160]
-	_ = x[opPlayerTradeSetSilverOrGold-161]
-	_ = x[opSendMiniMapPing-162]
-	_ = x[opStuck-163]
-	_ = x[opBuyRealEstate-164]
-	_ = x[opClaimRealEstate-165]
-	_ = x[opGiveUpRealEstate-166]
-	_ = x[opChangeRealEstateOutline-167]
-	_ = x[opGetMailInfos-168]
-	_ = x[opGetMailCount-169]
-	_ = x[opReadMail-170]
-	_ = x[opSendNewMail-171]
-	_ = x[opDeleteMail-172]
-	_ = x[opMarkMailUnread-173]
-	_ = x[opClaimAttachmentFromMail-174]
-	_ = x[opApplyToGuild-175]
-	_ = x[opAnswerGuildApplication-176]
-	_ = x[opRequestGuildFinderFilteredList-177]
-	_ = x[opUpdateGuildRecruitmentInfo-178]
-	_ = x[opRequestGuildRecruitmentInfo-179]
-	_ = x[opRequestGuildFinderNameSearch-180]
-	_ = x[opRequestGuildFinderRecommendedList-181]
-	_ = x[opRegisterChatPeer-182]
-	_ = x[opSendChatMessage-183]
-	_ = x[opSendModeratorMessage-184]
-	_ = x[opJoinChatChannel-185]
-	_ = x[opLeaveChatChannel-186]
-	_ = x[opSendWhisperMessage-187]
-	_ = x[opSay-188]
-	_ = x[opPlayEmote-189]
-	_ = x[opStopEmote-190]
-	_ = x[opGetClusterMapInfo-191]
-	_ = x[opAccessRightsChangeSettings-192]
-	_ = x[opMount-193]
-	_ = x[opMountCancel-194]
-	_ = x[opBuyJourney-195]
-	_ = x[opSetSaleStatusForEstate-196]
-	_ = x[opResolveGuildOrPlayerName-197]
-	_ = x[opGetRespawnInfos-198]
-	_ = x[opMakeHome-199]
-	_ = x[opLeaveHome-200]
-	_ = x[opResurrectionReply-201]
-	_ = x[opAllianceCreate-202]
-	_ = x[opAllianceDisband-203]
-	_ = x[opAllianceGetMemberInfos-204]
-	_ = x[opAllianceInvite-205]
-	_ = x[opAllianceAnswerInvitation-206]
-	_ = x[opAllianceCancelInvitation-207]
-	_ = x[opAllianceKickGuild-208]
-	_ = x[opAllianceLeave-209]
-	_ = x[opAllianceChangeGoldPaymentFlag-210]
-	_ = x[opAllianceGetDetailInfo-211]
-	_ = x[opGetIslandInfos-212]
-	_ = x[opBuyMyIsland-213]
-	_ = x[opBuyGuildIsland-214]
-	_ = x[opUpgradeMyIsland-215]
-	_ = x[opUpgradeGuildIsland-216]
-	_ = x[opTerritoryFillNutrition-217]
-	_ = x[opTeleportBack-218]
-	_ = x[opPartyInvitePlayer-219]
-	_ = x[opPartyRequestJoin-220]
-	_ = x[opPartyAnswerInvitation-221]
-	_ = x[opPartyAnswerJoinRequest-222]
-	_ = x[opPartyLeave-223]
-	_ = x[opPartyKickPlayer-224]
-	_ = x[opPartyMakeLeader-225]
-	_ = x[opPartyChangeLootSetting-226]
-	_ = x[opPartyMarkObject-227]
-	_ = x[opPartySetRole-228]
-	_ = x[opPartyChangeFactionWarfareRequestReinforcementsSetting-229]
-	_ = x[opSetGuildCodex-230]
-	_ = x[opExitEnterStart-231]
-	_ = x[opExitEnterCancel-232]
-	_ = x[opQuestGiverRequest-233]
-	_ = x[opGoldMarketGetBuyOffer-234]
-	_ = x[opGoldMarketGetBuyOfferFromSilver-235]
-	_ = x[opGoldMarketGetSellOffer-236]
-	_ = x[opGoldMarketGetSellOfferFromSilver-237]
-	_ = x[opGoldMarketBuyGold-238]
-	_ = x[opGoldMarketSellGold-239]
-	_ = x[opGoldMarketCreateSellOrder-240]
-	_ = x[opGoldMarketCreateBuyOrder-241]
-	_ = x[opGoldMarketGetInfos-242]
-	_ = x[opGoldMarketCancelOrder-243]
-	_ = x[opGoldMarketGetAverageInfo-244]
-	_ = x[opTreasureChestUsingStart-245]
-	_ = x[opTreasureChestUsingCancel-246]
-	_ = x[opUseLootChest-247]
-	_ = x[opUseShrine-248]
-	_ = x[opUseHellgateShrine-249]
-	_ = x[opGetSiegeBannerInfo-250]
-	_ = x[opLaborerStartJob-251]
-	_ = x[opLaborerTakeJobLoot-252]
-	_ = x[opLaborerDismiss-253]
-	_ = x[opLaborerMove-254]
-	_ = x[opLaborerBuyItem-255]
-	_ = x[opLaborerUpgrade-256]
-	_ = x[opBuyPremium-257]
-	_ = x[opRealEstateGetAuctionData-258]
-	_ = x[opRealEstateBidOnAuction-259]
-	_ = x[opFriendInvite-260]
-	_ = x[opFriendAnswerInvitation-261]
-	_ = x[opFriendCancelnvitation-262]
-	_ = x[opFriendRemove-263]
-	_ = x[opInventoryStack-264]
-	_ = x[opInventoryReorder-265]
-	_ = x[opInventoryDropAll-266]
-	_ = x[opInventoryAddToStacks-267]
-	_ = x[opEquipmentItemChangeSpell-268]
-	_ = x[opExpeditionRegister-269]
-	_ = x[opExpeditionRegisterCancel-270]
-	_ = x[opJoinExpedition-271]
-	_ = x[opDeclineExpeditionInvitation-272]
-	_ = x[opVoteStart-273]
-	_ = x[opVoteDoVote-274]
-	_ = x[opRatingDoRate-275]
-	_ = x[opEnteringExpeditionStart-276]
-	_ = x[opEnteringExpeditionCancel-277]
-	_ = x[opActivateExpeditionCheckPoint-278]
-	_ = x[opArenaRegister-279]
-	_ = x[opArenaAddInvite-280]
-	_ = x[opArenaRegisterCancel-281]
-	_ = x[opArenaLeave-282]
-	_ = x[opJoinArenaMatch-283]
-	_ = x[opDeclineArenaInvitation-284]
-	_ = x[opEnteringArenaStart-285]
-	_ = x[opEnteringArenaCancel-286]
-	_ = x[opArenaCustomMatch-287]
-	_ = x[opUpdateCharacterStatement-288]
-	_ = x[opBoostFarmable-289]
-	_ = x[opGetStrikeHistory-290]
-	_ = x[opUseFunction-291]
-	_ = x[opUsePortalEntrance-292]
-	_ = x[opResetPortalBinding-293]
-	_ = x[opQueryPortalBinding-294]
-	_ = x[opClaimPaymentTransaction-295]
-	_ = x[opChangeUseFlag-296]
-	_ = x[opClientPerformanceStats-297]
-	_ = x[opExtendedHardwareStats-298]
-	_ = x[opClientLowMemoryWarning-299]
-	_ = x[opTerritoryClaimStart-300]
-	_ = x[opTerritoryClaimCancel-301]
-	_ = x[opDeliverCarriableObjectStart-302]
-	_ = x[opDeliverCarriableObjectCancel-303]
-	_ = x[opTerritoryUpgradeWithPowerCrystal-304]
-	_ = x[opRequestAppStoreProducts-305]
-	_ = x[opVerifyProductPurchase-306]
-	_ = x[opQueryGuildPlayerStats-307]
-	_ = x[opQueryAllianceGuildStats-308]
-	_ = x[opTrackAchievements-309]
-	_ = x[opSetAchievementsAutoLearn-310]
-	_ = x[opDepositItemToGuildCurrency-311]
-	_ = x[opWithdrawalItemFromGuildCurrency-312]
-	_ = x[opAuctionSellSpecificItemRequest-313]
-	_ = x[opFishingStart-314]
-	_ = x[opFishingCasting-315]
-	_ = x[opFishingCast-316]
-	_ = x[opFishingCatch-317]
-	_ = x[opFishingPull-318]
-	_ = x[opFishingGiveLine-319]
-	_ = x[opFishingFinish-320]
-	_ = x[opFishingCancel-321]
-	_ = x[opCreateGuildAccessTag-322]
-	_ = x[opDeleteGuildAccessTag-323]
-	_ = x[opRenameGuildAccessTag-324]
-	_ = x[opFlagGuildAccessTagGuildPermission-325]
-	_ = x[opAssignGuildAccessTag-326]
-	_ = x[opRemoveGuildAccessTagFromPlayer-327]
-	_ = x[opModifyGuildAccessTagEditors-328]
-	_ = x[opRequestPublicAccessTags-329]
-	_ = x[opChangeAccessTagPublicFlag-330]
-	_ = x[opUpdateGuildAccessTag-331]
-	_ = x[opSteamStartMicrotransaction-332]
-	_ = x[opSteamFinishMicrotransaction-333]
-	_ = x[opRequestXboxPurchaseIntent-334]
-	_ = x[opCloseXboxPurchaseIntent-335]
-	_ = x[opSteamIdHasActiveAccount-336]
-	_ = x[opCheckEmailAccountState-337]
-	_ = x[opLinkAccountToSteamId-338]
-	_ = x[opEpicIdHasActiveAccount-339]
-	_ = x[opLinkAccountToEpicId-340]
-	_ = x[opXboxIdHasActiveAccount-341]
-	_ = x[opInAppConfirmPaymentGooglePlay-342]
-	_ = x[opInAppConfirmPaymentAppleAppStore-343]
-	_ = x[opInAppPurchaseRequest-344]
-	_ = x[opInAppPurchaseFailed-345]
-	_ = x[opCharacterSubscriptionInfo-346]
-	_ = x[opAccountSubscriptionInfo-347]
-	_ = x[opBuyGvgSeasonBooster-348]
-	_ = x[opChangeFlaggingPrepare-349]
-	_ = x[opOverCharge-350]
-	_ = x[opOverChargeEnd-351]
-	_ = x[opRequestTrusted-352]
-	_ = x[opChangeGuildLogo-353]
-	_ = x[opPartyFinderRegisterForUpdates-354]
-	_ = x[opPartyFinderUnregisterForUpdates-355]
-	_ = x[opPartyFinderEnlistNewPartySearch-356]
-	_ = x[opPartyFinderDeletePartySearch-357]
-	_ = x[opPartyFinderChangePartySearch-358]
-	_ = x[opPartyFinderChangeRole-359]
-	_ = x[opPartyFinderApplyForGroup-360]
-	_ = x[opPartyFinderAcceptOrDeclineApplyForGroup-361]
-	_ = x[opPartyFinderGetEquipmentSnapshot-362]
-	_ = x[opPartyFinderRegisterApplicants-363]
-	_ = x[opPartyFinderUnregisterApplicants-364]
-	_ = x[opPartyFinderFulltextSearch-365]
-	_ = x[opPartyFinderRequestEquipmentSnapshot-366]
-	_ = x[opGetPersonalSeasonTrackerData-367]
-	_ = x[opGetPersonalSeasonPastRewardData-368]
-	_ = x[opUseConsumableFromInventory-369]
-	_ = x[opClaimPersonalSeasonReward-370]
-	_ = x[opXignCodeMessageToServer-371]
-	_ = x[opBattlEyeMessageToServer-372]
-	_ = x[opSetNextTutorialState-373]
-	_ = x[opAddPlayerToMuteList-374]
-	_ = x[opRemovePlayerFromMuteList-375]
-	_ = x[opProductShopUserEvent-376]
-	_ = x[opGetVanityUnlocks-377]
-	_ = x[opBuyVanityUnlocks-378]
-	_ = x[opGetMountSkins-379]
-	_ = x[opSetMountSkin-380]
-	_ = x[opSetWardrobe-381]
-	_ = x[opChangeCustomization-382]
-	_ = x[opChangePlayerIslandData-383]
-	_ = x[opGetGuildChallengePoints-384]
-	_ = x[opSmartQueueJoin-385]
-	_ = x[opSmartQueueLeave-386]
-	_ = x[opSmartQueueSelectSpawnCluster-387]
-	_ = x[opUpgradeHideout-388]
-	_ = x[opInitHideoutAttackStart-389]
-	_ = x[opInitHideoutAttackCancel-390]
-	_ = x[opHideoutFillNutrition-391]
-	_ = x[opHideoutGetInfo-392]
-	_ = x[opHideoutGetOwnerInfo-393]
-	_ = x[opHideoutSetTribute-394]
-	_ = x[opHideoutUpgradeWithPowerCrystal-395]
-	_ = x[opHideoutDeclareHQ-396]
-	_ = x[opHideoutUndeclareHQ-397]
-	_ = x[opHideoutGetHQRequirements-398]
-	_ = x[opHideoutBoost-399]
-	_ = x[opHideoutBoostConstruction-400]
-	_ = x[opOpenWorldAttackScheduleStart-401]
-	_ = x[opOpenWorldAttackScheduleCancel-402]
-	_ = x[opOpenWorldAttackConquerStart-403]
-	_ = x[opOpenWorldAttackConquerCancel-404]
-	_ = x[opGetOpenWorldAttackDetails-405]
-	_ = x[opGetNextOpenWorldAttackScheduleTime-406]
-	_ = x[opRecoverVaultFromHideout-407]
-	_ = x[opGetGuildEnergyDrainInfo-408]
-	_ = x[opChannelingUpdate-409]
-	_ = x[opUseCorruptedShrine-410]
-	_ = x[opRequestEstimatedMarketValue-411]
-	_ = x[opLogFeedback-412]
-	_ = x[opGetInfamyInfo-413]
-	_ = x[opGetPartySmartClusterQueuePriority-414]
-	_ = x[opSetPartySmartClusterQueuePriority-415]
-	_ = x[opClientAntiAutoClickerInfo-416]
-	_ = x[opClientBotPatternDetectionInfo-417]
-	_ = x[opClientAntiGatherClickerInfo-418]
-	_ = x[opLoadoutCreate-419]
-	_ = x[opLoadoutRead-420]
-	_ = x[opLoadoutReadHeaders-421]
-	_ = x[opLoadoutUpdate-422]
-	_ = x[opLoadoutDelete-423]
-	_ = x[opLoadoutOrderUpdate-424]
-	_ = x[opLoadoutEquip-425]
-	_ = x[opBatchUseItemCancel-426]
-	_ = x[opEnlistFactionWarfare-427]
-	_ = x[opGetFactionWarfareWeeklyReport-428]
-	_ = x[opClaimFactionWarfareWeeklyReport-429]
-	_ = x[opGetFactionWarfareCampaignData-430]
-	_ = x[opClaimFactionWarfareItemReward-431]
-	_ = x[opSendMemoryConsumption-432]
-	_ = x[opPickupCarriableObjectStart-433]
-	_ = x[opPickupCarriableObjectCancel-434]
-	_ = x[opSetSavingChestLogsFlag-435]
-	_ = x[opGetSavingChestLogsFlag-436]
-	_ = x[opRegisterGuestAccount-437]
-	_ = x[opResendGuestAccountVerificationEmail-438]
-	_ = x[opDoSimpleActionStart-439]
-	_ = x[opDoSimpleActionCancel-440]
-	_ = x[opGetGvgSeasonContributionByActivity-441]
-	_ = x[opGetGvgSeasonContributionByCrystalLeague-442]
-	_ = x[opGetGuildMightCategoryContribution-443]
-	_ = x[opGetGuildMightCategoryOverview-444]
-	_ = x[opGetPvpChallengeData-445]
-	_ = x[opClaimPvpChallengeWeeklyReward-446]
-	_ = x[opGetPersonalMightStats-447]
-	_ = x[opGetPvpChallengeSeasonRewards-448]
-	_ = x[opGetPvpChallengeSeasonRewardItems-449]
-	_ = x[opClaimPvpChallengeSeasonRewards-450]
-	_ = x[opClaimPvpChallengeSeasonRewardItems-451]
-	_ = x[opAuctionGetLoadoutOffers-452]
-	_ = x[opAuctionBuyLoadoutOffer-453]
-	_ = x[opAccountDeletionRequest-454]
-	_ = x[opAccountReactivationRequest-455]
-	_ = x[opCreateModeratorNotesForAccount-456]
-	_ = x[opGetModeratorNotesForAccount-457]
-	_ = x[opGetModerationEscalationDefiniton-458]
-	_ = x[opEventBasedPopupAddSeen-459]
-	_ = x[opGetItemKillHistory-460]
-	_ = x[opGetVanityConsumables-461]
-	_ = x[opEquipKillEmote-462]
-	_ = x[opChangeKillEmotePlayOnKnockdownSetting-463]
-	_ = x[opBuyVanityConsumableCharges-464]
-	_ = x[opReclaimVanityItem-465]
-	_ = x[opGetArenaRankings-466]
-	_ = x[opGetCrystalLeagueStatistics-467]
-	_ = x[opSendOptionsLog-468]
-	_ = x[opSendControlsOptionsLog-469]
-	_ = x[opMistsUseImmediateReturnExit-470]
-	_ = x[opMistsUseStaticEntrance-471]
-	_ = x[opMistsUseCityRoadsEntrance-472]
-	_ = x[opChangeNewGuildMemberMail-473]
-	_ = x[opGetNewGuildMemberMail-474]
-	_ = x[opChangeGuildFactionAllegiance-475]
-	_ = x[opGetGuildFactionAllegiance-476]
-	_ = x[opGuildBannerChange-477]
-	_ = x[opGuildGetOptionalStats-478]
-	_ = x[opGuildSetOptionalStats-479]
-	_ = x[opGetPlayerInfoForStalk-480]
-	_ = x[opPayGoldForCharacterTypeChange-481]
-	_ = x[opQuickSellAuctionQueryAction-482]
-	_ = x[opQuickSellAuctionSellAction-483]
-	_ = x[opFcmTokenToServer-484]
-	_ = x[opApnsTokenToServer-485]
-	_ = x[opDeathRecap-486]
-	_ = x[opAuctionFetchFinishedAuctions-487]
-	_ = x[opAbortAuctionFetchFinishedAuctions-488]
-	_ = x[opRequestLegendaryEvenHistory-489]
-	_ = x[opPartyAnswerStartHuntRequest-490]
-	_ = x[opHuntAbort-491]
-	_ = x[opUseFindTrackSpellFromItemPrepare-492]
-	_ = x[opInteractWithTrackStart-493]
-	_ = x[opInteractWithTrackCancel-494]
-	_ = x[opTerritoryRaidStart-495]
-	_ = x[opTerritoryRaidCancel-496]
-	_ = x[opTerritoryClaimRaidedRawEnergyCrystalResult-497]
-	_ = x[opGvGSeasonPlayerGuildParticipationDetails-498]
-	_ = x[opDailyMightBonus-499]
-	_ = x[opClaimDailyMightBonus-500]
-	_ = x[opGetFortificationGroupInfo-501]
-	_ = x[opUpgradeFortificationGroup-502]
-	_ = x[opCancelUpgradeFortificationGroup-503]
-	_ = x[opDowngradeFortificationGroup-504]
-	_ = x[opGetClusterActivityChestEstimates-505]
-	_ = x[opPartyReadyCheckBegin-506]
-	_ = x[opPartyReadyCheckUpdate-507]
-	_ = x[opClaimAlbionJournalReward-508]
-	_ = x[opTrackAlbionJournalAchievements-509]
-	_ = x[opTrackAlbionJournalAchievementSubCategory-510]
-	_ = x[opRequestOutlandsTeleportationUsage-511]
-	_ = x[opPickupFromPiledObjectStart-512]
-	_ = x[opPickupFromPiledObjectCancel-513]
-	_ = x[opAssetOverview-514]
-	_ = x[opAssetOverviewTabs-515]
-	_ = x[opAssetOverviewTabContent-516]
-	_ = x[opAssetOverviewUnfreezeCache-517]
-	_ = x[opAssetOverviewSearch-518]
-	_ = x[opAssetOverviewSearchTabs-519]
-	_ = x[opAssetOverviewSearchTabContent-520]
-	_ = x[opAssetOverviewRecoverPlayerVault-521]
-	_ = x[opImmortalizeKillTrophy-522]
-	_ = x[opArmorySearch-523]
-	_ = x[opArmoryItemUsageStatistics-524]
-	_ = x[opArmoryActivityUsageStatistics-525]
-	_ = x[opHellDungeonUseStaticEntrance-526]
-	_ = x[opTravelIslandShowroom-527]
-	_ = x[opGetXuids-528]
-	_ = x[opXboxServiceTicket-529]
-	_ = x[opEvaluatePlatformPerks-530]
-	_ = x[opLinkAccountToXbox-531]
-	_ = x[opTravelFactionWarfarePortal-532]
-}
-
-const _OperationType_name = "opUnusedopPingopJoinopVersionedOperationopCreateAccountopLoginopCreateGuestAccountopSendCrashLogopSendTraceRouteopSendVfxStatsopSendGamePingInfoopCreateCharacteropDeleteCharacteropSelectCharacteropAcceptPopupsopRedeemKeycodeopGetGameServerByClusteropGetShopPurchaseUrlopGetReferralSeasonDetailsopGetReferralLinkopGetShopTilesForCategoryopMoveopAttackStartopCastStartopCastCancelopTerminateToggleSpellopChannelingCancelopAttackBuildingStartopInventoryDestroyItemopInventoryMoveItemopInventoryRecoverItemopInventoryRecoverAllItemsopInventorySplitStackopInventorySplitStackIntoopGetClusterDataopChangeClusteropConsoleCommandopChatMessageopReportClientErroropRegisterToObjectopUnRegisterFromObjectopCraftBuildingChangeSettingsopCraftBuildingTakeMoneyopRepairBuildingChangeSettingsopRepairBuildingTakeMoneyopActionBuildingChangeSettingsopHarvestStartopHarvestCancelopTakeSilveropActionOnBuildingStartopActionOnBuildingCancelopInstallResourceStartopInstallResourceCancelopInstallSilveropBuildingFillNutritionopBuildingChangeRenovationStateopBuildingBuySkinopBuildingClaimopBuildingGiveupopBuildingNutritionSilverStorageDepositopBuildingNutritionSilverStorageWithdrawopBuildingNutritionSilverRewardSetopConstructionSiteCreateopPlaceableObjectPlaceopPlaceableObjectPlaceCancelopPlaceableObjectPickupopFurnitureObjectUseopFarmableHarvestopFarmableFinishGrownItemopFarmableDestroyopFarmableGetProductopFarmableFillopTearDownConstructionSiteopAuctionCreateOfferopAuctionCreateRequestopAuctionGetOffersopAuctionGetRequestsopAuctionBuyOfferopAuctionAbortAuctionopAuctionModifyAuctionopAuctionAbortOfferopAuctionAbortRequestopAuctionSellRequestopAuctionGetFinishedAuctionsopAuctionGetFinishedAuctionsCountopAuctionFetchAuctionopAuctionGetMyOpenOffersopAuctionGetMyOpenRequestsopAuctionGetMyOpenAuctionsopAuctionGetItemAverageStatsopAuctionGetItemAverageValueopAuctionGetLowestOfferPricesopContainerOpenopContainerCloseopContainerManageSubContaineropRespawnopSuicideopJoinGuildopLeaveGuildopCreateGuildopInviteToGuildopDeclineGuildInvitationopKickFromGuildopInstantJoinGuildopDuellingChallengePlayeropDuellingAcceptChallengeopDuellingDenyChallengeopChangeClusterTaxopClaimTerritoryopGiveUpTerritoryopChangeTerritoryAccessRightsopGetMonolithInfoopGetClaimInfoopGetAttackInfoopGetTerritorySeasonPointsopGetAttackScheduleopGetMatchesopGetMatchDetailsopJoinMatchopLeaveMatchopGetClusterInstanceInfoForStaticClusteropChangeChatSettingsopLogoutStartopLogoutCancelopClaimOrbStartopClaimOrbCancelopMatchLootChestOpeningStartopMatchLootChestOpeningCancelopDepositToGuildAccountopWithdrawalFromAccountopChangeGuildPayUpkeepFlagopChangeGuildTaxopGetMyTerritoriesopMorganaCommandopGetServerInfoopSubscribeToClusteropAnswerMercenaryInvitationopGetCharacterEquipmentopGetCharacterSteamAchievementsopGetCharacterStatsopGetKillHistoryDetailsopReSpecAchievementopChangeAvataropGetRankingsopGetRankopGetGvgSeasonRankingsopGetGvgSeasonRankopGetGvgSeasonHistoryRankingsopGetGvgSeasonGuildMemberHistoryopKickFromGvGMatchopGetCrystalLeagueDailySeasonPointsopGetChestLogsopGetAccessRightLogsopGetGuildAccountLogsopGetGuildAccountLogsLargeAmountopInviteToPlayerTradeopPlayerTradeCancelopPlayerTradeInvitationAcceptopPlayerTradeAddItemopPlayerTradeRemoveItemopPlayerTradeAcceptTradeopPlayerTradeSetSilverOrGoldopSendMiniMapPingopStuckopBuyRealEstateopClaimRealEstateopGiveUpRealEstateopChangeRealEstateOutlineopGetMailInfosopGetMailCountopReadMailopSendNewMailopDeleteMailopMarkMailUnreadopClaimAttachmentFromMailopApplyToGuildopAnswerGuildApplicationopRequestGuildFinderFilteredListopUpdateGuildRecruitmentInfoopRequestGuildRecruitmentInfoopRequestGuildFinderNameSearchopRequestGuildFinderRecommendedListopRegisterChatPeeropSendChatMessageopSendModeratorMessageopJoinChatChannelopLeaveChatChannelopSendWhisperMessageopSayopPlayEmoteopStopEmoteopGetClusterMapInfoopAccessRightsChangeSettingsopMountopMountCancelopBuyJourneyopSetSaleStatusForEstateopResolveGuildOrPlayerNameopGetRespawnInfosopMakeHomeopLeaveHomeopResurrectionReplyopAllianceCreateopAllianceDisbandopAllianceGetMemberInfosopAllianceInviteopAllianceAnswerInvitationopAllianceCancelInvitationopAllianceKickGuildopAllianceLeaveopAllianceChangeGoldPaymentFlagopAllianceGetDetailInfoopGetIslandInfosopBuyMyIslandopBuyGuildIslandopUpgradeMyIslandopUpgradeGuildIslandopTerritoryFillNutritionopTeleportBackopPartyInvitePlayeropPartyRequestJoinopPartyAnswerInvitationopPartyAnswerJoinRequestopPartyLeaveopPartyKickPlayeropPartyMakeLeaderopPartyChangeLootSettingopPartyMarkObjectopPartySetRoleopPartyChangeFactionWarfareRequestReinforcementsSettingopSetGuildCodexopExitEnterStartopExitEnterCancelopQuestGiverRequestopGoldMarketGetBuyOfferopGoldMarketGetBuyOfferFromSilveropGoldMarketGetSellOfferopGoldMarketGetSellOfferFromSilveropGoldMarketBuyGoldopGoldMarketSellGoldopGoldMarketCreateSellOrderopGoldMarketCreateBuyOrderopGoldMarketGetInfosopGoldMarketCancelOrderopGoldMarketGetAverageInfoopTreasureChestUsingStartopTreasureChestUsingCancelopUseLootChestopUseShrineopUseHellgateShrineopGetSiegeBannerInfoopLaborerStartJobopLaborerTakeJobLootopLaborerDismissopLaborerMoveopLaborerBuyItemopLaborerUpgradeopBuyPremiumopRealEstateGetAuctionDataopRealEstateBidOnAuctionopFriendInviteopFriendAnswerInvitationopFriendCancelnvitationopFriendRemoveopInventoryStackopInventoryReorderopInventoryDropAllopInventoryAddToStacksopEquipmentItemChangeSpellopExpeditionRegisteropExpeditionRegisterCancelopJoinExpeditionopDeclineExpeditionInvitationopVoteStartopVoteDoVoteopRatingDoRateopEnteringExpeditionStartopEnteringExpeditionCancelopActivateExpeditionCheckPointopArenaRegisteropArenaAddInviteopArenaRegisterCancelopArenaLeaveopJoinArenaMatchopDeclineArenaInvitationopEnteringArenaStartopEnteringArenaCancelopArenaCustomMatchopUpdateCharacterStatementopBoostFarmableopGetStrikeHistoryopUseFunctionopUsePortalEntranceopResetPortalBindingopQueryPortalBindingopClaimPaymentTransactionopChangeUseFlagopClientPerformanceStatsopExtendedHardwareStatsopClientLowMemoryWarningopTerritoryClaimStartopTerritoryClaimCancelopDeliverCarriableObjectStartopDeliverCarriableObjectCancelopTerritoryUpgradeWithPowerCrystalopRequestAppStoreProductsopVerifyProductPurchaseopQueryGuildPlayerStatsopQueryAllianceGuildStatsopTrackAchievementsopSetAchievementsAutoLearnopDepositItemToGuildCurrencyopWithdrawalItemFromGuildCurrencyopAuctionSellSpecificItemRequestopFishingStartopFishingCastingopFishingCastopFishingCatchopFishingPullopFishingGiveLineopFishingFinishopFishingCancelopCreateGuildAccessTagopDeleteGuildAccessTagopRenameGuildAccessTagopFlagGuildAccessTagGuildPermissionopAssignGuildAccessTagopRemoveGuildAccessTagFromPlayeropModifyGuildAccessTagEditorsopRequestPublicAccessTagsopChangeAccessTagPublicFlagopUpdateGuildAccessTagopSteamStartMicrotransactionopSteamFinishMicrotransactionopRequestXboxPurchaseIntentopCloseXboxPurchaseIntentopSteamIdHasActiveAccountopCheckEmailAccountStateopLinkAccountToSteamIdopEpicIdHasActiveAccountopLinkAccountToEpicIdopXboxIdHasActiveAccountopInAppConfirmPaymentGooglePlayopInAppConfirmPaymentAppleAppStoreopInAppPurchaseRequestopInAppPurchaseFailedopCharacterSubscriptionInfoopAccountSubscriptionInfoopBuyGvgSeasonBoosteropChangeFlaggingPrepareopOverChargeopOverChargeEndopRequestTrustedopChangeGuildLogoopPartyFinderRegisterForUpdatesopPartyFinderUnregisterForUpdatesopPartyFinderEnlistNewPartySearchopPartyFinderDeletePartySearchopPartyFinderChangePartySearchopPartyFinderChangeRoleopPartyFinderApplyForGroupopPartyFinderAcceptOrDeclineApplyForGroupopPartyFinderGetEquipmentSnapshotopPartyFinderRegisterApplicantsopPartyFinderUnregisterApplicantsopPartyFinderFulltextSearchopPartyFinderRequestEquipmentSnapshotopGetPersonalSeasonTrackerDataopGetPersonalSeasonPastRewardDataopUseConsumableFromInventoryopClaimPersonalSeasonRewardopXignCodeMessageToServeropBattlEyeMessageToServeropSetNextTutorialStateopAddPlayerToMuteListopRemovePlayerFromMuteListopProductShopUserEventopGetVanityUnlocksopBuyVanityUnlocksopGetMountSkinsopSetMountSkinopSetWardrobeopChangeCustomizationopChangePlayerIslandDataopGetGuildChallengePointsopSmartQueueJoinopSmartQueueLeaveopSmartQueueSelectSpawnClusteropUpgradeHideoutopInitHideoutAttackStartopInitHideoutAttackCancelopHideoutFillNutritionopHideoutGetInfoopHideoutGetOwnerInfoopHideoutSetTributeopHideoutUpgradeWithPowerCrystalopHideoutDeclareHQopHideoutUndeclareHQopHideoutGetHQRequirementsopHideoutBoostopHideoutBoostConstructionopOpenWorldAttackScheduleStartopOpenWorldAttackScheduleCancelopOpenWorldAttackConquerStartopOpenWorldAttackConquerCancelopGetOpenWorldAttackDetailsopGetNextOpenWorldAttackScheduleTimeopRecoverVaultFromHideoutopGetGuildEnergyDrainInfoopChannelingUpdateopUseCorruptedShrineopRequestEstimatedMarketValueopLogFeedbackopGetInfamyInfoopGetPartySmartClusterQueuePriorityopSetPartySmartClusterQueuePriorityopClientAntiAutoClickerInfoopClientBotPatternDetectionInfoopClientAntiGatherClickerInfoopLoadoutCreateopLoadoutReadopLoadoutReadHeadersopLoadoutUpdateopLoadoutDeleteopLoadoutOrderUpdateopLoadoutEquipopBatchUseItemCancelopEnlistFactionWarfareopGetFactionWarfareWeeklyReportopClaimFactionWarfareWeeklyReportopGetFactionWarfareCampaignDataopClaimFactionWarfareItemRewardopSendMemoryConsumptionopPickupCarriableObjectStartopPickupCarriableObjectCancelopSetSavingChestLogsFlagopGetSavingChestLogsFlagopRegisterGuestAccountopResendGuestAccountVerificationEmailopDoSimpleActionStartopDoSimpleActionCancelopGetGvgSeasonContributionByActivityopGetGvgSeasonContributionByCrystalLeagueopGetGuildMightCategoryContributionopGetGuildMightCategoryOverviewopGetPvpChallengeDataopClaimPvpChallengeWeeklyRewardopGetPersonalMightStatsopGetPvpChallengeSeasonRewardsopGetPvpChallengeSeasonRewardItemsopClaimPvpChallengeSeasonRewardsopClaimPvpChallengeSeasonRewardItemsopAuctionGetLoadoutOffersopAuctionBuyLoadoutOfferopAccountDeletionRequestopAccountReactivationRequestopCreateModeratorNotesForAccountopGetModeratorNotesForAccountopGetModerationEscalationDefinitonopEventBasedPopupAddSeenopGetItemKillHistoryopGetVanityConsumablesopEquipKillEmoteopChangeKillEmotePlayOnKnockdownSettingopBuyVanityConsumableChargesopReclaimVanityItemopGetArenaRankingsopGetCrystalLeagueStatisticsopSendOptionsLogopSendControlsOptionsLogopMistsUseImmediateReturnExitopMistsUseStaticEntranceopMistsUseCityRoadsEntranceopChangeNewGuildMemberMailopGetNewGuildMemberMailopChangeGuildFactionAllegianceopGetGuildFactionAllegianceopGuildBannerChangeopGuildGetOptionalStatsopGuildSetOptionalStatsopGetPlayerInfoForStalkopPayGoldForCharacterTypeChangeopQuickSellAuctionQueryActionopQuickSellAuctionSellActionopFcmTokenToServeropApnsTokenToServeropDeathRecapopAuctionFetchFinishedAuctionsopAbortAuctionFetchFinishedAuctionsopRequestLegendaryEvenHistoryopPartyAnswerStartHuntRequestopHuntAbortopUseFindTrackSpellFromItemPrepareopInteractWithTrackStartopInteractWithTrackCancelopTerritoryRaidStartopTerritoryRaidCancelopTerritoryClaimRaidedRawEnergyCrystalResultopGvGSeasonPlayerGuildParticipationDetailsopDailyMightBonusopClaimDailyMightBonusopGetFortificationGroupInfoopUpgradeFortificationGroupopCancelUpgradeFortificationGroupopDowngradeFortificationGroupopGetClusterActivityChestEstimatesopPartyReadyCheckBeginopPartyReadyCheckUpdateopClaimAlbionJournalRewardopTrackAlbionJournalAchievementsopTrackAlbionJournalAchievementSubCategoryopRequestOutlandsTeleportationUsageopPickupFromPiledObjectStartopPickupFromPiledObjectCancelopAssetOverviewopAssetOverviewTabsopAssetOverviewTabContentopAssetOverviewUnfreezeCacheopAssetOverviewSearchopAssetOverviewSearchTabsopAssetOverviewSearchTabContentopAssetOverviewRecoverPlayerVaultopImmortalizeKillTrophyopArmorySearchopArmoryItemUsageStatisticsopArmoryActivityUsageStatisticsopHellDungeonUseStaticEntranceopTravelIslandShowroomopGetXuidsopXboxServiceTicketopEvaluatePlatformPerksopLinkAccountToXboxopTravelFactionWarfarePortal"
-
-var _OperationType_index = [...]uint16{0, 8, 14, 20, 40, 55, 62, 82, 96, 112, 126, 144, 161, 178, 195, 209, 224, 248, 268, 294, 311, 336, 342, 355, 366, 378, 400, 418, 439, 461, 480, 502, 528, 549, 574, 590, 605, 621, 634, 653, 671, 693, 722, 746, 776, 801, 831, 845, 860, 872, 895, 919, 941, 964, 979, 1002, 1033, 1050, 1065, 1081, 1120, 1160, 1194, 1218, 1240, 1268, 1291, 1311, 1328, 1353, 1370, 1390, 1404, 1430, 1450, 1472, 1490, 1510, 1527, 1548, 1570, 1589, 1610, 1630, 1658, 1691, 1712, 1736, 1762, 1788, 1816, 1844, 1873, 1888, 1904, 1933, 1942, 1951, 1962, 1974, 1987, 2002, 2026, 2041, 2059, 2084, 2109, 2132, 2150, 2166, 2183, 2212, 2229, 2243, 2258, 2284, 2303, 2315, 2332, 2343, 2355, 2395, 2415, 2428, 2442, 2457, 2473, 2501, 2530, 2553, 2576, 2602, 2618, 2636, 2652, 2667, 2687, 2714, 2737, 2768, 2787, 2810, 2829, 2843, 2856, 2865, 2887, 2905, 2934, 2966, 2984, 3019, 3033, 3053, 3074, 3106, 3127, 3146, 3175, 3195, 3218, 3242, 3270, 3287, 3294, 3309, 3326, 3344, 3369, 3383, 3397, 3407, 3420, 3432, 3448, 3473, 3487, 3511, 3543, 3571, 3600, 3630, 3665, 3683, 3700, 3722, 3739, 3757, 3777, 3782, 3793, 3804, 3823, 3851, 3858, 3871, 3883, 3907, 3933, 3950, 3960, 3971, 3990, 4006, 4023, 4047, 4063, 4089, 4115, 4134, 4149, 4180, 4203, 4219, 4232, 4248, 4265, 4285, 4309, 4323, 4342, 4360, 4383, 4407, 4419, 4436, 4453, 4477, 4494, 4508, 4563, 4578, 4594, 4611, 4630, 4653, 4686, 4710, 4744, 4763, 4783, 4810, 4836, 4856, 4879, 4905, 4930, 4956, 4970, 4981, 5000, 5020, 5037, 5057, 5073, 5086, 5102, 5118, 5130, 5156, 5180, 5194, 5218, 5241, 5255, 5271, 5289, 5307, 5329, 5355, 5375, 5401, 5417, 5446, 5457, 5469, 5483, 5508, 5534, 5564, 5579, 5595, 5616, 5628, 5644, 5668, 5688, 5709, 5727, 5753, 5768, 5786, 5799, 5818, 5838, 5858, 5883, 5898, 5922, 5945, 5969, 5990, 6012, 6041, 6071, 6105, 6130, 6153, 6176, 6201, 6220, 6246, 6274, 6307, 6339, 6353, 6369, 6382, 6396, 6409, 6426, 6441, 6456, 6478, 6500, 6522, 6557, 6579, 6611, 6640, 6665, 6692, 6714, 6742, 6771, 6798, 6823, 6848, 6872, 6894, 6918, 6939, 6963, 6994, 7028, 7050, 7071, 7098, 7123, 7144, 7167, 7179, 7194, 7210, 7227, 7258, 7291, 7324, 7354, 7384, 7407, 7433, 7474, 7507, 7538, 7571, 7598, 7635, 7665, 7698, 7726, 7753, 7778, 7803, 7825, 7846, 7872, 7894, 7912, 7930, 7945, 7959, 7972, 7993, 8017, 8042, 8058, 8075, 8105, 8121, 8145, 8170, 8192, 8208, 8229, 8248, 8280, 8298, 8318, 8344, 8358, 8384, 8414, 8445, 8474, 8504, 8531, 8567, 8592, 8617, 8635, 8655, 8684, 8697, 8712, 8747, 8782, 8809, 8840, 8869, 8884, 8897, 8917, 8932, 8947, 8967, 8981, 9001, 9023, 9054, 9087, 9118, 9149, 9172, 9200, 9229, 9253, 9277, 9299, 9336, 9357, 9379, 9415, 9456, 9491, 9522, 9543, 9574, 9597, 9627, 9661, 9693, 9729, 9754, 9778, 9802, 9830, 9862, 9891, 9925, 9949, 9969, 9991, 10007, 10046, 10074, 10093, 10111, 10139, 10155, 10179, 10208, 10232, 10259, 10285, 10308, 10338, 10365, 10384, 10407, 10430, 10453, 10484, 10513, 10541, 10559, 10578, 10590, 10620, 10655, 10684, 10713, 10724, 10758, 10782, 10807, 10827, 10848, 10892, 10934, 10951, 10973, 11000, 11027, 11060, 11089, 11123, 11145, 11168, 11194, 11226, 11268, 11303, 11331, 11360, 11375, 11394, 11419, 11447, 11468, 11493, 11524, 11557, 11580, 11594, 11621, 11652, 11682, 11704, 11714, 11733, 11756, 11775, 11803}
-
-func (i OperationType) String() string {
-	if i >= OperationType(len(_OperationType_index)-1) {
-		return "OperationType(" + strconv.FormatInt(int64(i), 10) + ")"
-	}
-	return _OperationType_name[_OperationType_index[i]:_OperationType_index[i+1]]
-}
@@ -0,0 +1,123 @@
+package client
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ao-data/albiondata-client/log"
+)
+
+const (
+	// Size of the router queue between the listeners and the ordered lane
+	routerQueueSize = 1000
+
+	// Size of the queue in front of the worker pool
+	workerQueueSize = 1000
+
+	// Number of workers running slow handlers and uploads
+	workerCount = 4
+
+	// How long a full queue may hold up its producer before the item is
+	// dropped. Blocking longer would stall packet capture.
+	enqueueTimeout = time.Second
+
+	// How often the pipeline metrics are logged
+	pipelineStatsInterval = time.Minute
+)
+
+// slowOperation is implemented by handlers that may block for a while. They
+// run on the worker pool instead of the ordered lane, so they do not hold up
+// the messages behind them, and they see the state as of when they run.
+type slowOperation interface {
+	slow()
+}
+
+// queueStats are the metrics of one queue in the pipeline
+type queueStats struct {
+	queued    atomic.Int64
+	processed atomic.Int64
+	dropped   atomic.Int64
+	maxDepth  atomic.Int64
+}
+
+func (s *queueStats) observeDepth(depth int) {
+	for {
+		max := s.maxDepth.Load()
+		if int64(depth) <= max || s.maxDepth.CompareAndSwap(max, int64(depth)) {
+			return
+		}
+	}
+}
+
+// enqueue sends item to queue, waiting at most enqueueTimeout when it is full.
+// It reports whether the item was queued.
+func enqueue[T any](queue chan T, item T, stats *queueStats) bool {
+	select {
+	case queue <- item:
+	default:
+		timer := time.NewTimer(enqueueTimeout)
+		defer timer.Stop()
+
+		select {
+		case queue <- item:
+		case <-timer.C:
+			stats.dropped.Add(1)
+			return false
+		}
+	}
+
+	stats.queued.Add(1)
+	stats.observeDepth(len(queue))
+	return true
+}
+
+// workerPool runs jobs on a fixed number of goroutines
+type workerPool struct {
+	jobs  chan func()
+	done  sync.WaitGroup
+	stats queueStats
+}
+
+func newWorkerPool(workers int, size int) *workerPool {
+	p := &workerPool{
+		jobs: make(chan func(), size),
+	}
+
+	for i := 0; i < workers; i++ {
+		p.done.Add(1)
+		go p.work()
+	}
+
+	return p
+}
+
+func (p *workerPool) work() {
+	defer p.done.Done()
+
+	for job := range p.jobs {
+		job()
+		p.stats.processed.Add(1)
+	}
+}
+
+// submit queues a job, it is dropped if the pool cannot keep up
+func (p *workerPool) submit(job func()) bool {
+	if !enqueue(p.jobs, job, &p.stats) {
+		log.Warn("The worker pool is full, dropping a job. The uploads cannot keep up with the captured data.")
+		return false
+	}
+	return true
+}
+
+// close runs the queued jobs and waits for them. Nothing may be submitted
+// after it is called.
+func (p *workerPool) close() {
+	close(p.jobs)
+	p.done.Wait()
+}
+
+func logQueueStats(name string, queue int, capacity int, stats *queueStats) {
+	log.Debugf("Pipeline %v: depth %d/%d (max %d), queued %d, processed %d, dropped %d",
+		name, queue, capacity, stats.maxDepth.Load(), stats.queued.Load(), stats.processed.Load(), stats.dropped.Load())
+}
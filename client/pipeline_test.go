@@ -0,0 +1,90 @@
+package client
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+// sequencedOperation records the order operations are processed in
+type sequencedOperation struct {
+	n    int
+	seen *[]int
+	mu   *sync.Mutex
+}
+
+func (op sequencedOperation) Process(state *albionState) {
+	op.mu.Lock()
+	defer op.mu.Unlock()
+	*op.seen = append(*op.seen, op.n)
+}
+
+func TestEnqueueDropsOnTimeout(t *testing.T) {
+	var stats queueStats
+	queue := make(chan int, 1)
+	if !enqueue(queue, 1, &stats) {
+		t.Fatal("the item was dropped from an empty queue")
+	}
+
+	started := time.Now()
+	if enqueue(queue, 2, &stats) {
+		t.Fatal("the item was queued to a full queue")
+	}
+	if waited := time.Since(started); waited < enqueueTimeout {
+		t.Errorf("dropped after %v, want %v", waited, enqueueTimeout)
+	}
+
+	// room made within the timeout lets the item in
+	go func() {
+		time.Sleep(enqueueTimeout / 10)
+		<-queue
+	}()
+	if !enqueue(queue, 3, &stats) {
+		t.Error("the item was dropped though the queue had room in time")
+	}
+
+	if queued, dropped := stats.queued.Load(), stats.dropped.Load(); queued != 2 || dropped != 1 {
+		t.Errorf("queued %d and dropped %d, want 2 and 1", queued, dropped)
+	}
+	if depth := stats.maxDepth.Load(); depth != 1 {
+		t.Errorf("max depth %d, want 1", depth)
+	}
+}
+
+func TestRouterKeepsOrderAndDropsWhenFull(t *testing.T) {
+	saved := ConfigGlobal.RecordPath
+	defer func() { ConfigGlobal.RecordPath = saved }()
+	ConfigGlobal.RecordPath = ""
+
+	var mu sync.Mutex
+	var seen []int
+	r := newRouter()
+	state := &albionState{}
+
+	// the router is not running yet, the queue fills up
+	for n := 0; n < routerQueueSize; n++ {
+		r.enqueue(sequencedOperation{n, &seen, &mu}, state)
+	}
+	r.enqueue(sequencedOperation{-1, &seen, &mu}, state)
+	if dropped := r.stats.dropped.Load(); dropped != 1 {
+		t.Fatalf("dropped %d operations, want the 1 past the queue size", dropped)
+	}
+
+	go r.run()
+	r.enqueue(sequencedOperation{routerQueueSize, &seen, &mu}, state)
+	r.stop()
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(seen) != routerQueueSize+1 {
+		t.Fatalf("processed %d operations, want %d", len(seen), routerQueueSize+1)
+	}
+	for i, n := range seen {
+		if n != i {
+			t.Fatalf("operation %d was processed as number %d", n, i)
+		}
+	}
+	if processed := r.stats.processed.Load(); processed != routerQueueSize+1 {
+		t.Errorf("counted %d processed, want %d", processed, routerQueueSize+1)
+	}
+}
@@ -0,0 +1,211 @@
+package client
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ao-data/albiondata-client/log"
+)
+
+const (
+	// How long a pow may take to solve before the upload is retried
+	powSolveTimeout = 2 * time.Minute
+	// How many solutions a worker tries between looking for a cancellation
+	powBatchSize = 1024
+)
+
+// powSolver solves the proofs of work of all the uploaders on a fixed number
+// of goroutines. Every solve is handed to all the workers, so one solve uses
+// the whole budget and concurrent solves share it.
+type powSolver struct {
+	workers int
+	tasks   chan *powTask
+
+	stats powSolverStats
+}
+
+type powSolverStats struct {
+	solved   atomic.Int64
+	failed   atomic.Int64
+	attempts atomic.Int64
+	total    atomic.Int64 // nanoseconds
+	slowest  atomic.Int64 // nanoseconds
+}
+
+type powTask struct {
+	ctx    context.Context
+	pow    Pow
+	result chan string
+}
+
+var (
+	powSolvers     atomic.Pointer[powSolver]
+	powSolversOnce sync.Once
+)
+
+// sharedPowSolver returns the solver all the pow uploaders share, started on
+// first use with the configured cpu budget
+func sharedPowSolver() *powSolver {
+	powSolversOnce.Do(func() {
+		powSolvers.Store(newPowSolver(powWorkers()))
+	})
+	return powSolvers.Load()
+}
+
+// logPowStats logs the statistics of the shared solver, if it was started
+func logPowStats() {
+	if s := powSolvers.Load(); s != nil {
+		s.logStats()
+	}
+}
+
+// powWorkers is the cpu budget of the solver: the configured number of
+// workers, else a quarter of the cores, or all of them with -no-limit
+func powWorkers() int {
+	if ConfigGlobal.PowWorkers > 0 {
+		return ConfigGlobal.PowWorkers
+	}
+	if ConfigGlobal.NoCPULimit {
+		return runtime.NumCPU()
+	}
+	if workers := runtime.NumCPU() / 4; workers > 1 {
+		return workers
+	}
+	return 1
+}
+
+func newPowSolver(workers int) *powSolver {
+	s := &powSolver{
+		workers: workers,
+		tasks:   make(chan *powTask),
+	}
+	for i := 0; i < workers; i++ {
+		go s.work()
+	}
+	log.Debugf("Solving proofs of work on %d workers", workers)
+	return s
+}
+
+// solve finds a solution of the pow, giving up when ctx is done or after
+// powSolveTimeout
+func (s *powSolver) solve(ctx context.Context, pow Pow) (string, error) {
+	if bits := hex.EncodedLen(sha256.Size) * 8; len(pow.Wanted) > bits {
+		return "", fmt.Errorf("pow wants %d bits, a hash only has %d", len(pow.Wanted), bits)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, powSolveTimeout)
+	// stops the other workers once one found the solution
+	defer cancel()
+
+	started := time.Now()
+	task := &powTask{ctx: ctx, pow: pow, result: make(chan string, 1)}
+
+	go func() {
+		for i := 0; i < s.workers; i++ {
+			select {
+			case s.tasks <- task:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	select {
+	case solution := <-task.result:
+		s.record(time.Since(started))
+		return solution, nil
+	case <-ctx.Done():
+		s.stats.failed.Add(1)
+		return "", fmt.Errorf("solving pow: %v", ctx.Err())
+	}
+}
+
+func (s *powSolver) work() {
+	for task := range s.tasks {
+		if solution, ok := s.grind(task); ok {
+			select {
+			case task.result <- solution:
+			default:
+			}
+		}
+	}
+}
+
+// grind tries random solutions until one fits or the task is done
+func (s *powSolver) grind(task *powTask) (string, bool) {
+	for {
+		if task.ctx.Err() != nil {
+			return "", false
+		}
+		for i := 0; i < powBatchSize; i++ {
+			randhex := randomHex(16)
+			if checkPow(task.pow, randhex) {
+				s.stats.attempts.Add(int64(i + 1))
+				return randhex, true
+			}
+		}
+		s.stats.attempts.Add(powBatchSize)
+	}
+}
+
+func (s *powSolver) record(took time.Duration) {
+	s.stats.solved.Add(1)
+	s.stats.total.Add(int64(took))
+	for {
+		slowest := s.stats.slowest.Load()
+		if int64(took) <= slowest || s.stats.slowest.CompareAndSwap(slowest, int64(took)) {
+			return
+		}
+	}
+}
+
+func (s *powSolver) logStats() {
+	solved := s.stats.solved.Load()
+	if solved == 0 && s.stats.failed.Load() == 0 {
+		return
+	}
+
+	var average time.Duration
+	if solved > 0 {
+		average = time.Duration(s.stats.total.Load() / solved)
+	}
+	log.Debugf("Pow solver: %d workers, solved %d, failed %d, tried %d, average %v, slowest %v",
+		s.workers, solved, s.stats.failed.Load(), s.stats.attempts.Load(),
+		average.Round(time.Millisecond), time.Duration(s.stats.slowest.Load()).Round(time.Millisecond))
+}
+
+// Generates a random hex string e.g.: faa2743d9181dca5
+func randomHex(n int) string {
+	b := make([]byte, n)
+	rand.Read(b)
+	dst := make([]byte, n*2)
+	hex.Encode(dst, b)
+	return string(dst)
+}
+
+// checkPow tells whether randhex solves the pow: the leading bits of the
+// hex encoded hash of the challenge must be the wanted ones
+func checkPow(pow Pow, randhex string) bool {
+	var hexBuf [64]byte
+
+	hash := sha256.Sum256([]byte("aod^" + randhex + "^" + pow.Key))
+	hex.Encode(hexBuf[:], hash[:])
+
+	for idx := 0; idx < len(pow.Wanted); idx++ {
+		bit := byte('0')
+		if (hexBuf[idx/8]>>(7-idx%8))&1 == 1 {
+			bit = '1'
+		}
+		if pow.Wanted[idx] != bit {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,126 @@
+package client
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ao-data/albiondata-client/lib"
+	"github.com/ao-data/albiondata-client/mockingest"
+)
+
+// referencePow is how the ingest checks a solution: the bits of the hex of
+// the hash start with the wanted ones
+func referencePow(pow Pow, randhex string) bool {
+	hash := sha256.Sum256([]byte("aod^" + randhex + "^" + pow.Key))
+	var bits strings.Builder
+	for _, c := range []byte(hex.EncodeToString(hash[:])) {
+		fmt.Fprintf(&bits, "%08b", c)
+	}
+	return strings.HasPrefix(bits.String(), pow.Wanted)
+}
+
+func TestCheckPow(t *testing.T) {
+	wanted := []string{"", "0", "1", "00", "01", "0110", "011000", "01100011"}
+	matched := 0
+	for i := 0; i < 2000; i++ {
+		randhex := randomHex(16)
+		for _, w := range wanted {
+			pow := Pow{Key: fmt.Sprint("key-", i%7), Wanted: w}
+			got, want := checkPow(pow, randhex), referencePow(pow, randhex)
+			if got != want {
+				t.Fatalf("checkPow(%q, %q) = %v, want %v", w, randhex, got, want)
+			}
+			if got && len(w) == 8 {
+				matched++
+			}
+		}
+	}
+	// the hex digits of a hash all start with 0011 or 0110
+	if matched == 0 {
+		t.Error("no solution matched the longest prefix, the check is not exercised")
+	}
+}
+
+func TestPowSolverSolves(t *testing.T) {
+	s := newPowSolver(2)
+
+	pow := Pow{Key: "key", Wanted: "0110001"}
+	solution, err := s.solve(context.Background(), pow)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !referencePow(pow, solution) {
+		t.Errorf("%q does not solve the pow", solution)
+	}
+	if solved := s.stats.solved.Load(); solved != 1 {
+		t.Errorf("counted %d solved, want 1", solved)
+	}
+
+	if _, err := s.solve(context.Background(), Pow{Key: "key", Wanted: strings.Repeat("0", 513)}); err == nil {
+		t.Error("a pow wanting more bits than a hash has was taken")
+	}
+}
+
+func TestPowSolverCancels(t *testing.T) {
+	s := newPowSolver(2)
+
+	// every hex digit starts with 0011 or 0110, this never matches
+	impossible := Pow{Key: "key", Wanted: "1111"}
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	started := time.Now()
+	if _, err := s.solve(ctx, impossible); err == nil {
+		t.Fatal("the cancelled pow was solved")
+	}
+	if took := time.Since(started); took > 5*time.Second {
+		t.Errorf("solving gave up after %v", took)
+	}
+	if failed := s.stats.failed.Load(); failed != 1 {
+		t.Errorf("counted %d failed, want 1", failed)
+	}
+
+	// the workers left the cancelled pow for the next one
+	done := make(chan error, 1)
+	go func() {
+		_, err := s.solve(context.Background(), Pow{Key: "key", Wanted: "0"})
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Error(err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("the workers are still on the cancelled pow")
+	}
+}
+
+func TestPowUploaderAgainstTheIngest(t *testing.T) {
+	server := mockingest.NewServer(8)
+	ingest := httptest.NewServer(server)
+	defer ingest.Close()
+
+	u, _, err := createUploader("http+pow://" + strings.TrimPrefix(ingest.URL, "http://"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer u.(*httpUploaderPow).close()
+
+	if err := u.sendToIngest(goldPrices, lib.NatsGoldPricesIngest, stateSnapshot{}, "id"); err != nil {
+		t.Fatal(err)
+	}
+	received := server.Received(lib.NatsGoldPricesIngest)
+	if len(received) != 1 || !received[0].Pow {
+		t.Errorf("the ingest took %+v, want one upload with a solved pow", received)
+	}
+	if server.Rejected() != 0 {
+		t.Errorf("the ingest rejected %d uploads", server.Rejected())
+	}
+}
@@ -0,0 +1,152 @@
+package client
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/ao-data/albiondata-client/log"
+)
+
+// realm is a game server region and where its data is sent
+type realm struct {
+	ID        int      `mapstructure:"id"`
+	Name      string   `mapstructure:"name"`
+	IngestURL string   `mapstructure:"ingest"`
+	Ranges    []string `mapstructure:"ranges"`
+
+	networks []*net.IPNet
+}
+
+// The realms known when this client was built. Realms in config.yaml replace
+// them.
+var defaultRealms = []realm{
+	{ID: 1, Name: "west", IngestURL: "https+pow://pow.west.albion-online-data.com", Ranges: []string{"5.188.125.0/24"}},
+	{ID: 2, Name: "east", IngestURL: "https+pow://pow.east.albion-online-data.com", Ranges: []string{"5.45.187.0/24"}},
+	{ID: 3, Name: "europe", IngestURL: "https+pow://pow.europe.albion-online-data.com", Ranges: []string{"193.169.238.0/24"}},
+}
+
+// realmRegistry finds the realm of a game server by its IP
+type realmRegistry struct {
+	realms []realm
+
+	// game server IPs outside every range, reported once each
+	mu      sync.Mutex
+	unknown map[string]bool
+}
+
+// realms is the realm registry in use
+var realms = mustRealmRegistry(defaultRealms)
+
+func newRealmRegistry(list []realm) (*realmRegistry, error) {
+	registry := &realmRegistry{unknown: make(map[string]bool)}
+
+	ids := make(map[int]bool, len(list))
+	for _, r := range list {
+		if r.ID == 0 {
+			return nil, fmt.Errorf("realm %q needs an id other than 0", r.Name)
+		}
+		if ids[r.ID] {
+			return nil, fmt.Errorf("duplicate realm id %d", r.ID)
+		}
+		ids[r.ID] = true
+
+		r.networks = nil
+		for _, cidr := range r.Ranges {
+			_, network, err := net.ParseCIDR(cidr)
+			if err != nil {
+				return nil, fmt.Errorf("realm %q: %v", r.Name, err)
+			}
+			r.networks = append(r.networks, network)
+		}
+		registry.realms = append(registry.realms, r)
+	}
+
+	return registry, nil
+}
+
+func mustRealmRegistry(list []realm) *realmRegistry {
+	registry, err := newRealmRegistry(list)
+	if err != nil {
+		panic(fmt.Sprintf("invalid realms: %v", err))
+	}
+	return registry
+}
+
+// loadRealms replaces the realm registry with the realms from the config
+func loadRealms(list []realm) error {
+	registry, err := newRealmRegistry(list)
+	if err != nil {
+		return fmt.Errorf("could not load the realms from config.yaml: %v", err)
+	}
+
+	realms = registry
+	return nil
+}
+
+// lookup returns the realm the IP belongs to
+func (r *realmRegistry) lookup(ip string) (realm, bool) {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return realm{}, false
+	}
+
+	for _, candidate := range r.realms {
+		for _, network := range candidate.networks {
+			if network.Contains(parsed) {
+				return candidate, true
+			}
+		}
+	}
+	return realm{}, false
+}
+
+// reportUnknown warns once about a public IP talking on the game port that
+// is in no realm, so the ranges can be updated
+func (r *realmRegistry) reportUnknown(ip string) {
+	parsed := net.ParseIP(ip)
+	if parsed == nil || parsed.IsPrivate() || parsed.IsLoopback() || parsed.IsLinkLocalUnicast() {
+		return
+	}
+	if _, ok := r.lookup(ip); ok {
+		return
+	}
+
+	r.mu.Lock()
+	seen := r.unknown[ip]
+	r.unknown[ip] = true
+	r.mu.Unlock()
+
+	if !seen {
+		log.Warnf("Game server %v is not in any known realm, its data cannot be uploaded. Please report it so the realm list can be updated, or add it to Realms in config.yaml.", ip)
+	}
+}
+
+// findServerAddress returns the IP of the first "ip:port" string in the
+// params. The login and cluster responses carry the game server to connect
+// to this way.
+func findServerAddress(params map[uint8]interface{}) (string, bool) {
+	keys := make([]int, 0, len(params))
+	for key := range params {
+		keys = append(keys, int(key))
+	}
+	sort.Ints(keys)
+
+	for _, key := range keys {
+		address, ok := params[uint8(key)].(string)
+		if !ok {
+			continue
+		}
+		host, port, err := net.SplitHostPort(address)
+		if err != nil || net.ParseIP(host) == nil {
+			continue
+		}
+		if _, err := strconv.ParseUint(port, 10, 16); err != nil {
+			continue
+		}
+		return host, true
+	}
+	return "", false
+}
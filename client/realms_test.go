@@ -0,0 +1,115 @@
+package client
+
+import (
+	"testing"
+)
+
+func TestRealmLookup(t *testing.T) {
+	registry := mustRealmRegistry([]realm{
+		{ID: 1, Name: "west", Ranges: []string{"5.188.125.0/24"}},
+		{ID: 2, Name: "east", Ranges: []string{"5.45.187.0/24", "10.20.0.0/16"}},
+		{ID: 3, Name: "ipv6", Ranges: []string{"2001:db8::/32"}},
+	})
+
+	tests := []struct {
+		ip   string
+		name string
+	}{
+		{"5.188.125.1", "west"},
+		{"5.188.125.255", "west"},
+		{"5.188.126.1", ""},
+		{"5.45.187.40", "east"},
+		// the second range of a realm
+		{"10.20.200.3", "east"},
+		{"2001:db8::1", "ipv6"},
+		{"192.168.1.20", ""},
+		{"", ""},
+		{"not an ip", ""},
+	}
+	for _, tt := range tests {
+		r, ok := registry.lookup(tt.ip)
+		if ok != (tt.name != "") || r.Name != tt.name {
+			t.Errorf("lookup(%q) = %q, %v, want %q", tt.ip, r.Name, ok, tt.name)
+		}
+	}
+}
+
+func TestRealmRegistryRefusesBadRealms(t *testing.T) {
+	for name, list := range map[string][]realm{
+		"no id":        {{Name: "west", Ranges: []string{"5.188.125.0/24"}}},
+		"duplicate id": {{ID: 1, Name: "west"}, {ID: 1, Name: "east"}},
+		"bad range":    {{ID: 1, Name: "west", Ranges: []string{"5.188.125.0"}}},
+	} {
+		if _, err := newRealmRegistry(list); err == nil {
+			t.Errorf("%v: the realms were taken", name)
+		}
+	}
+}
+
+func TestDefaultRealms(t *testing.T) {
+	for _, r := range defaultRealms {
+		if len(r.Ranges) == 0 {
+			t.Errorf("realm %v has no range", r.Name)
+			continue
+		}
+		if _, err := parseTarget(r.IngestURL); err != nil {
+			t.Errorf("realm %v: ingest %q: %v", r.Name, r.IngestURL, err)
+		}
+	}
+}
+
+func TestFindServerAddress(t *testing.T) {
+	tests := []struct {
+		params map[uint8]interface{}
+		want   string
+	}{
+		{map[uint8]interface{}{0: "5.188.125.40:5056"}, "5.188.125.40"},
+		// the first by key, whatever the map order
+		{map[uint8]interface{}{3: "5.45.187.1:5056", 1: "5.188.125.40:5056", 2: "text"}, "5.188.125.40"},
+		{map[uint8]interface{}{0: "[2001:db8::1]:5056"}, "2001:db8::1"},
+		{map[uint8]interface{}{0: "3005", 1: int32(5056), 2: []string{"5.188.125.40:5056"}}, ""},
+		{map[uint8]interface{}{0: "albion.example.com:5056"}, ""},
+		{map[uint8]interface{}{0: "5.188.125.40:port", 1: "5.188.125.40:70000"}, ""},
+		{nil, ""},
+	}
+	for _, tt := range tests {
+		got, ok := findServerAddress(tt.params)
+		if ok != (tt.want != "") || got != tt.want {
+			t.Errorf("findServerAddress(%v) = %q, %v, want %q", tt.params, got, ok, tt.want)
+		}
+	}
+}
+
+// Behind a proxy the packets come from its address, the handshake still
+// names the game server and with it the realm
+func TestHandshakeRealmWinsOverTheSource(t *testing.T) {
+	state := newAlbionState(stateSnapshot{Session: "udp:55123"}, nil)
+	defer state.stop()
+
+	state.setGameServer("203.0.113.9")
+	if _, ok := state.snapshot().realm(); ok {
+		t.Fatal("the proxy was taken for a game server")
+	}
+
+	kind, params := loadFixture(t, "operations/GetGameServerByCluster.response.json")
+	operations, err := decodeMessage(kind, params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	operations[0].Process(state)
+
+	snapshot := state.snapshot()
+	r, ok := snapshot.realm()
+	if !ok || r.Name != "west" {
+		t.Fatalf("realm %q, %v, want west", r.Name, ok)
+	}
+	if snapshot.AODataServerID != r.ID || snapshot.AODataIngestBaseURL != r.IngestURL {
+		t.Errorf("server %d at %q, want the ones of west", snapshot.AODataServerID, snapshot.AODataIngestBaseURL)
+	}
+
+	// packets from another realm's server do not change it
+	state.setGameServer("5.45.187.40")
+	if r, _ := state.snapshot().realm(); r.Name != "west" {
+		t.Errorf("realm %q after packets from east, want the announced west", r.Name)
+	}
+}
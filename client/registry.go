@@ -0,0 +1,171 @@
+package client
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/ao-data/albiondata-client/log"
+)
+
+// messageKind tells apart the three kinds of photon messages we decode
+type messageKind int
+
+const (
+	kindRequest messageKind = iota
+	kindResponse
+	kindEvent
+)
+
+func (kind messageKind) String() string {
+	switch kind {
+	case kindRequest:
+		return "request"
+	case kindResponse:
+		return "response"
+	case kindEvent:
+		return "event"
+	}
+	return fmt.Sprintf("messageKind(%d)", int(kind))
+}
+
+// codeName resolves a numeric code of this kind in the loaded code table
+func (kind messageKind) codeName(code int16) string {
+	if kind == kindEvent {
+		return EventType(code).String()
+	}
+	return OperationType(code).String()
+}
+
+// handlerFactory returns a fresh struct to decode the params into. The struct
+// handles the message through its Process method.
+type handlerFactory func() operation
+
+// unhandledCode is a code we have seen on the wire but have no handler for
+type unhandledCode struct {
+	Kind  messageKind
+	Code  int16
+	Name  string
+	Count int
+}
+
+type handlerRegistry struct {
+	handlers map[messageKind]map[string][]handlerFactory
+
+	mu        sync.Mutex
+	unhandled map[messageKind]map[int16]int
+}
+
+func newHandlerRegistry() *handlerRegistry {
+	return &handlerRegistry{
+		handlers:  make(map[messageKind]map[string][]handlerFactory),
+		unhandled: make(map[messageKind]map[int16]int),
+	}
+}
+
+// handlers holds everything the operation_*.go and event_*.go files register
+// in their init functions
+var handlers = newHandlerRegistry()
+
+// register adds a handler for the named code. Several handlers may be
+// registered for the same code, all of them get the message.
+func (r *handlerRegistry) register(kind messageKind, name string, factory handlerFactory) {
+	if r.handlers[kind] == nil {
+		r.handlers[kind] = make(map[string][]handlerFactory)
+	}
+	r.handlers[kind][name] = append(r.handlers[kind][name], factory)
+}
+
+// lookup returns the handlers for a numeric code and remembers codes nobody
+// handles
+func (r *handlerRegistry) lookup(kind messageKind, code int16) []handlerFactory {
+	factories := r.handlers[kind][kind.codeName(code)]
+	if len(factories) == 0 {
+		r.mu.Lock()
+		if r.unhandled[kind] == nil {
+			r.unhandled[kind] = make(map[int16]int)
+		}
+		r.unhandled[kind][code]++
+		r.mu.Unlock()
+	}
+	return factories
+}
+
+// names returns the registered code names of a kind, sorted
+func (r *handlerRegistry) names(kind messageKind) []string {
+	var names []string
+	for name := range r.handlers[kind] {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// unhandledCodes lists the codes seen so far that had no handler, the most
+// frequent first
+func (r *handlerRegistry) unhandledCodes() []unhandledCode {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var result []unhandledCode
+	for kind, counts := range r.unhandled {
+		for code, count := range counts {
+			result = append(result, unhandledCode{
+				Kind:  kind,
+				Code:  code,
+				Name:  kind.codeName(code),
+				Count: count,
+			})
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Count != result[j].Count {
+			return result[i].Count > result[j].Count
+		}
+		if result[i].Kind != result[j].Kind {
+			return result[i].Kind < result[j].Kind
+		}
+		return result[i].Code < result[j].Code
+	})
+
+	return result
+}
+
+// checkCodeTable warns about handlers whose name is missing from the table,
+// they would never be called
+func (r *handlerRegistry) checkCodeTable(table *codeTable) {
+	for kind := range r.handlers {
+		for _, name := range r.names(kind) {
+			var found bool
+			if kind == kindEvent {
+				_, found = table.event(name)
+			} else {
+				_, found = table.operation(name)
+			}
+			if !found {
+				log.Warnf("The code table has no %v named %v, it will not be handled", kind, name)
+			}
+		}
+	}
+}
+
+func registerRequest(name string, factory handlerFactory) {
+	handlers.register(kindRequest, name, factory)
+}
+
+func registerResponse(name string, factory handlerFactory) {
+	handlers.register(kindResponse, name, factory)
+}
+
+func registerEvent(name string, factory handlerFactory) {
+	handlers.register(kindEvent, name, factory)
+}
+
+// logUnhandledCodes lists what we ignored so far, for finding messages worth
+// handling
+func logUnhandledCodes() {
+	for _, c := range handlers.unhandledCodes() {
+		log.Debugf("Unhandled %v: [%d]%v seen %d times", c.Kind, c.Code, c.Name, c.Count)
+	}
+}
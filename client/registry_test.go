@@ -0,0 +1,70 @@
+package client
+
+import (
+	"reflect"
+	"testing"
+)
+
+type firstHandler struct{}
+
+func (firstHandler) Process(state *albionState) {}
+
+type secondHandler struct{}
+
+func (secondHandler) Process(state *albionState) {}
+
+func TestRegistryLookup(t *testing.T) {
+	r := newHandlerRegistry()
+	r.register(kindResponse, "AuctionGetOffers", func() operation { return &firstHandler{} })
+	// registered twice, both get the message
+	r.register(kindResponse, "AuctionGetOffers", func() operation { return &secondHandler{} })
+	r.register(kindRequest, "AuctionGetOffers", func() operation { return &firstHandler{} })
+	r.register(kindEvent, "Respawn", func() operation { return &firstHandler{} })
+
+	code, _ := codes.operation("AuctionGetOffers")
+	factories := r.lookup(kindResponse, int16(code))
+	if len(factories) != 2 {
+		t.Fatalf("got %d handlers, want the 2 registered", len(factories))
+	}
+	if _, ok := factories[0]().(*firstHandler); !ok {
+		t.Errorf("the first handler is %T, want the one registered first", factories[0]())
+	}
+	if _, ok := factories[1]().(*secondHandler); !ok {
+		t.Errorf("the second handler is %T", factories[1]())
+	}
+
+	// the same code is another name among the events
+	if got := r.lookup(kindEvent, int16(code)); len(got) != 0 {
+		t.Errorf("event %d has %d handlers, want none", code, len(got))
+	}
+	respawn, _ := codes.event("Respawn")
+	if got := r.lookup(kindEvent, int16(respawn)); len(got) != 1 {
+		t.Errorf("Respawn has %d handlers, want 1", len(got))
+	}
+
+	if got, want := r.names(kindResponse), []string{"AuctionGetOffers"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("names = %v, want %v", got, want)
+	}
+}
+
+func TestRegistryCountsUnhandledCodes(t *testing.T) {
+	r := newHandlerRegistry()
+	join, _ := codes.operation("Join")
+	r.register(kindResponse, "Join", func() operation { return &firstHandler{} })
+
+	r.lookup(kindResponse, int16(join))
+	r.lookup(kindRequest, int16(join))
+	for i := 0; i < 3; i++ {
+		r.lookup(kindEvent, 1)
+	}
+	r.lookup(kindRequest, 9999)
+
+	want := []unhandledCode{
+		{Kind: kindEvent, Code: 1, Name: EventType(1).String(), Count: 3},
+		{Kind: kindRequest, Code: int16(join), Name: "Join", Count: 1},
+		{Kind: kindRequest, Code: 9999, Name: "OperationType(9999)", Count: 1},
+	}
+	if got := r.unhandledCodes(); !reflect.DeepEqual(got, want) {
+		t.Errorf("unhandled codes %+v, want %+v", got, want)
+	}
+}
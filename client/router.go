@@ -3,25 +3,36 @@ package client
 import (
 	"encoding/gob"
 	"os"
+	"time"
 
 	"github.com/ao-data/albiondata-client/log"
 	photon "github.com/ao-data/photon-spectator"
 )
 
+// routedOperation is an operation with the state of the game session it
+// was captured in
+type routedOperation struct {
+	op    operation
+	state *albionState
+}
+
 //Router struct definitions
 type Router struct {
-	albionstate         *albionState
-	newOperation        chan operation
+	sessions            *sessionStates
+	newOperation        chan routedOperation
 	recordPhotonCommand chan photon.PhotonCommand
 	quit                chan bool
+	done                chan struct{}
+	stats               queueStats
 }
 
 func newRouter() *Router {
 	return &Router{
-		albionstate:         &albionState{LocationId: ""},
-		newOperation:        make(chan operation, 1000),
+		sessions:            newSessionStates(),
+		newOperation:        make(chan routedOperation, routerQueueSize),
 		recordPhotonCommand: make(chan photon.PhotonCommand, 1000),
 		quit:                make(chan bool, 1),
+		done:                make(chan struct{}),
 	}
 }
 
@@ -29,7 +40,8 @@ func (r *Router) run() {
 	var encoder *gob.Encoder
 	var file *os.File
 	if ConfigGlobal.RecordPath != "" {
-		file, err := os.Create(ConfigGlobal.RecordPath)
+		var err error
+		file, err = os.Create(ConfigGlobal.RecordPath)
 		if err != nil {
 			log.Error("Could not open commands output file ", err)
 		} else {
@@ -37,10 +49,24 @@ func (r *Router) run() {
 		}
 	}
 
+	ticker := time.NewTicker(pipelineStatsInterval)
+	defer ticker.Stop()
+	defer close(r.done)
+
 	for {
 		select {
 		case <-r.quit:
 			log.Debug("Closing router...")
+			// what was captured before the stop is still handled
+			for len(r.newOperation) > 0 {
+				routed := <-r.newOperation
+				r.process(routed.op, routed.state)
+			}
+			for encoder != nil && len(r.recordPhotonCommand) > 0 {
+				if err := encoder.Encode(<-r.recordPhotonCommand); err != nil {
+					log.Error("Could not encode command ", err)
+				}
+			}
 			if file != nil {
 				err := file.Close()
 				if err != nil {
@@ -48,8 +74,13 @@ func (r *Router) run() {
 				}
 			}
 			return
-		case op := <-r.newOperation:
-			go op.Process(r.albionstate)
+		case routed := <-r.newOperation:
+			r.process(routed.op, routed.state)
+		case <-ticker.C:
+			logQueueStats("router", len(r.newOperation), cap(r.newOperation), &r.stats)
+			logQueueStats("workers", len(dis.workers.jobs), cap(dis.workers.jobs), &dis.workers.stats)
+			dis.uploads.logStats()
+			logPowStats()
 		case command := <-r.recordPhotonCommand:
 			if encoder != nil {
 				err := encoder.Encode(command)
@@ -60,3 +91,30 @@ func (r *Router) run() {
 		}
 	}
 }
+
+// stop processes the operations still queued and waits for the router to
+// exit. Nothing may be enqueued after it is called.
+func (r *Router) stop() {
+	r.quit <- true
+	<-r.done
+}
+
+// enqueue hands an operation to the router, to be run against the state of
+// its game session. Operations are processed in the order they were
+// captured. If the router falls behind for longer than enqueueTimeout the
+// operation is dropped.
+func (r *Router) enqueue(op operation, state *albionState) {
+	if !enqueue(r.newOperation, routedOperation{op: op, state: state}, &r.stats) {
+		log.Warnf("The router queue is full, dropping %T", op)
+	}
+}
+
+// process runs an operation in the ordered lane, unless it is slow
+func (r *Router) process(op operation, state *albionState) {
+	if _, ok := op.(slowOperation); ok {
+		dis.workers.submit(func() { op.Process(state) })
+	} else {
+		op.Process(state)
+	}
+	r.stats.processed.Add(1)
+}
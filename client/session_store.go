@@ -0,0 +1,209 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/ao-data/albiondata-client/lib"
+	"github.com/ao-data/albiondata-client/log"
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+const (
+	stateFileName = "albiondata-client.state.json"
+
+	// A saved player older than this is not restored, the player has
+	// probably moved on since
+	sessionMaxAge = time.Hour
+
+	// The game server port, the local end of this connection identifies the
+	// game session
+	gameServerPort = 5056
+)
+
+// persistedPlayer is the player of one game session
+type persistedPlayer struct {
+	SavedAt             time.Time       `json:"SavedAt"`
+	Session             string          `json:"Session"`
+	CharacterId         lib.CharacterID `json:"CharacterId"`
+	CharacterName       string          `json:"CharacterName"`
+	LocationId          string          `json:"LocationId"`
+	AODataServerID      int             `json:"AODataServerID"`
+	AODataIngestBaseURL string          `json:"AODataIngestBaseURL"`
+}
+
+// snapshot returns the state the player is restored to
+func (p persistedPlayer) snapshot() stateSnapshot {
+	return stateSnapshot{
+		Location:            lib.ParseLocation(p.LocationId),
+		CharacterId:         p.CharacterId,
+		CharacterName:       p.CharacterName,
+		AODataServerID:      p.AODataServerID,
+		AODataIngestBaseURL: p.AODataIngestBaseURL,
+		Session:             p.Session,
+	}
+}
+
+// persistedSession is what survives a restart of the client. The players are
+// keyed by their game session, a game client still running after the restart
+// keeps its session.
+type persistedSession struct {
+	Players map[string]persistedPlayer `json:"Players"`
+	Mails   []MailInfo                 `json:"Mails"`
+}
+
+// sessionFile keeps the last known sessions on disk
+type sessionFile struct {
+	path string
+
+	mu      sync.Mutex
+	session persistedSession
+}
+
+func newSessionFile(path string) *sessionFile {
+	return &sessionFile{
+		path:    path,
+		session: persistedSession{Players: make(map[string]persistedPlayer)},
+	}
+}
+
+// load returns the saved players that are recent enough to be trusted, and
+// the saved mails, which are kept until they expire
+func (f *sessionFile) load() ([]persistedPlayer, []MailInfo) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	data, err := os.ReadFile(f.path)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.Warnf("Could not read the saved session: %v", err)
+		}
+		return nil, nil
+	}
+
+	var session persistedSession
+	if err := json.Unmarshal(data, &session); err != nil {
+		log.Warnf("Could not parse the saved session: %v", err)
+		return nil, nil
+	}
+	f.session.Mails = session.Mails
+
+	var players []persistedPlayer
+	for key, player := range session.Players {
+		age := time.Since(player.SavedAt)
+		if age > sessionMaxAge {
+			log.Debugf("Not restoring the saved player %v, it is %v old", player.CharacterName, age.Round(time.Second))
+			continue
+		}
+		f.session.Players[key] = player
+		players = append(players, player)
+	}
+
+	return players, session.Mails
+}
+
+// savePlayer stores the player part of the state of a session
+func (f *sessionFile) savePlayer(s stateSnapshot) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	now := time.Now()
+	for key, player := range f.session.Players {
+		if now.Sub(player.SavedAt) > sessionMaxAge {
+			delete(f.session.Players, key)
+		}
+	}
+
+	f.session.Players[s.Session] = persistedPlayer{
+		SavedAt:             now,
+		Session:             s.Session,
+		CharacterId:         s.CharacterId,
+		CharacterName:       s.CharacterName,
+		LocationId:          s.Location.ID,
+		AODataServerID:      s.AODataServerID,
+		AODataIngestBaseURL: s.AODataIngestBaseURL,
+	}
+	f.write()
+}
+
+// saveMails stores the mail info cache
+func (f *sessionFile) saveMails(mails []MailInfo) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.session.Mails = append([]MailInfo(nil), mails...)
+	f.write()
+}
+
+// write must be called with the mutex held
+func (f *sessionFile) write() {
+	data, err := json.MarshalIndent(f.session, "", "  ")
+	if err != nil {
+		log.Errorf("Could not encode the session: %v", err)
+		return
+	}
+
+	if err := os.MkdirAll(filepath.Dir(f.path), 0755); err != nil {
+		log.Errorf("Could not save the session: %v", err)
+		return
+	}
+
+	// Write next to the file and rename, so a crash never leaves half a file
+	tmp := f.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		log.Errorf("Could not save the session: %v", err)
+		return
+	}
+	if err := os.Rename(tmp, f.path); err != nil {
+		log.Errorf("Could not save the session: %v", err)
+	}
+}
+
+// persistedPlayerChanged tells whether a change touched what we save
+func persistedPlayerChanged(prev stateSnapshot, next stateSnapshot) bool {
+	return prev.Session != next.Session ||
+		prev.CharacterId != next.CharacterId ||
+		prev.CharacterName != next.CharacterName ||
+		prev.Location.ID != next.Location.ID ||
+		prev.AODataServerID != next.AODataServerID ||
+		prev.AODataIngestBaseURL != next.AODataIngestBaseURL
+}
+
+// fromGameServer tells whether a packet was sent by the game server, its
+// source is then the server and not our own address
+func fromGameServer(packet gopacket.Packet) bool {
+	if udp, ok := packet.Layer(layers.LayerTypeUDP).(*layers.UDP); ok {
+		return udp.SrcPort == gameServerPort
+	}
+	if tcp, ok := packet.Layer(layers.LayerTypeTCP).(*layers.TCP); ok {
+		return tcp.SrcPort == gameServerPort
+	}
+	return false
+}
+
+// packetSession identifies the game session of a packet by the local end of
+// the game server connection. It is empty for other packets.
+func packetSession(packet gopacket.Packet) string {
+	if udp, ok := packet.Layer(layers.LayerTypeUDP).(*layers.UDP); ok {
+		switch {
+		case udp.SrcPort == gameServerPort:
+			return fmt.Sprintf("udp:%d", udp.DstPort)
+		case udp.DstPort == gameServerPort:
+			return fmt.Sprintf("udp:%d", udp.SrcPort)
+		}
+	}
+	if tcp, ok := packet.Layer(layers.LayerTypeTCP).(*layers.TCP); ok {
+		switch {
+		case tcp.SrcPort == gameServerPort:
+			return fmt.Sprintf("tcp:%d", tcp.DstPort)
+		case tcp.DstPort == gameServerPort:
+			return fmt.Sprintf("tcp:%d", tcp.SrcPort)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,111 @@
+package client
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ao-data/albiondata-client/lib"
+)
+
+func TestSessionFileRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), stateFileName)
+
+	f := newSessionFile(path)
+	player := stateSnapshot{
+		Location:            lib.ParseLocation("3005"),
+		CharacterId:         lib.CharacterID("01234567-89ab-cdef-0123-456789abcdef"),
+		CharacterName:       "Trader",
+		AODataServerID:      1,
+		AODataIngestBaseURL: "https+pow://pow.west.albion-online-data.com",
+		Session:             "udp:55123",
+	}
+	f.savePlayer(player)
+	mails := []MailInfo{{ID: 1, LocationID: "3005", OrderType: "FINISHED", Expires: time.Now().Add(time.Hour).Unix()}}
+	f.saveMails(mails)
+
+	players, restored := newSessionFile(path).load()
+	if len(players) != 1 {
+		t.Fatalf("restored %d players, want 1", len(players))
+	}
+	if got := players[0].snapshot(); !reflect.DeepEqual(got, player) {
+		t.Errorf("restored the player %+v, want %+v", got, player)
+	}
+	if !reflect.DeepEqual(restored, mails) {
+		t.Errorf("restored the mails %+v, want %+v", restored, mails)
+	}
+	if _, err := os.Stat(path + ".tmp"); !os.IsNotExist(err) {
+		t.Errorf("the temporary file was left behind: %v", err)
+	}
+}
+
+func TestSessionFileMaxAge(t *testing.T) {
+	path := filepath.Join(t.TempDir(), stateFileName)
+
+	session := persistedSession{Players: map[string]persistedPlayer{
+		"udp:1": {SavedAt: time.Now().Add(-2 * sessionMaxAge), Session: "udp:1", CharacterName: "Old"},
+		"udp:2": {SavedAt: time.Now().Add(-sessionMaxAge / 2), Session: "udp:2", CharacterName: "Recent"},
+	}}
+	data, err := json.Marshal(session)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	f := newSessionFile(path)
+	players, _ := f.load()
+	if len(players) != 1 || players[0].CharacterName != "Recent" {
+		t.Fatalf("restored %+v, want only the recent player", players)
+	}
+
+	// the old player is not written back either
+	f.savePlayer(stateSnapshot{Session: "udp:3", CharacterName: "New"})
+	data, err = os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var saved persistedSession
+	if err := json.Unmarshal(data, &saved); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := saved.Players["udp:1"]; ok || len(saved.Players) != 2 {
+		t.Errorf("saved the players %v, want the recent and the new one", saved.Players)
+	}
+}
+
+func TestSessionFileIgnoresBadFiles(t *testing.T) {
+	dir := t.TempDir()
+
+	if players, mails := newSessionFile(filepath.Join(dir, "missing.json")).load(); players != nil || mails != nil {
+		t.Errorf("a missing file restored %v and %v", players, mails)
+	}
+
+	path := filepath.Join(dir, stateFileName)
+	if err := os.WriteFile(path, []byte(`{"Players":`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if players, mails := newSessionFile(path).load(); players != nil || mails != nil {
+		t.Errorf("a broken file restored %v and %v", players, mails)
+	}
+}
+
+func TestSessionFileCreatesItsDirectory(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("AppData", t.TempDir())
+
+	path := dataPath(stateFileName)
+	if !filepath.IsAbs(path) || filepath.Base(path) != stateFileName {
+		t.Fatalf("the state defaults to %q, want it in the config directory", path)
+	}
+
+	newSessionFile(path).savePlayer(stateSnapshot{Session: "udp:1", CharacterName: "Trader"})
+	if players, _ := newSessionFile(path).load(); len(players) != 1 {
+		t.Errorf("restored %d players from %v, want 1", len(players), path)
+	}
+}
@@ -0,0 +1,99 @@
+package client
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ao-data/albiondata-client/lib"
+	"github.com/ao-data/albiondata-client/log"
+)
+
+// How long a game session may stay silent before its state is dropped
+const sessionIdleTimeout = time.Hour
+
+// sessionStates keeps one albionState per game session, so two game clients
+// on one machine do not overwrite each other's player and location
+type sessionStates struct {
+	mu     sync.Mutex
+	states map[string]*trackedState
+
+	// Where new states are saved, if anywhere
+	file *sessionFile
+}
+
+type trackedState struct {
+	state    *albionState
+	lastSeen time.Time
+}
+
+func newSessionStates() *sessionStates {
+	return &sessionStates{
+		states: make(map[string]*trackedState),
+	}
+}
+
+// restore loads the players saved by a previous run and keeps saving them
+// from now on. Must be called before the states are used.
+func (s *sessionStates) restore(file *sessionFile) {
+	players, mails := file.load()
+
+	mailInfos.restore(mails)
+	mailInfos.onChange = file.saveMails
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.file = file
+	now := time.Now()
+	for _, player := range players {
+		log.Infof("Restoring %v in %v from the previous run (saved %v).", player.CharacterName, lib.ParseLocation(player.LocationId), player.SavedAt.Format(time.RFC3339))
+		s.states[player.Session] = &trackedState{
+			state:    newAlbionState(player.snapshot(), file),
+			lastSeen: now,
+		}
+	}
+}
+
+// get returns the state of a game session, creating it on first sight.
+// Messages outside of a game connection, like the login server or offline
+// commands, share the state of the empty session.
+func (s *sessionStates) get(session string) *albionState {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	if tracked, ok := s.states[session]; ok {
+		tracked.lastSeen = now
+		return tracked.state
+	}
+
+	s.expire(now)
+
+	initial := stateSnapshot{Session: session}
+	if session != "" {
+		log.Infof("Tracking a new game session (%v).", session)
+
+		// the login handshake naming the game server comes before the game
+		// connection, outside of it
+		if outside, ok := s.states[""]; ok {
+			initial.AnnouncedServerIP = outside.state.snapshot().AnnouncedServerIP
+		}
+	}
+
+	state := newAlbionState(initial, s.file)
+	s.states[session] = &trackedState{state: state, lastSeen: now}
+	return state
+}
+
+// expire drops the game sessions that went silent. Must be called with the
+// mutex held.
+func (s *sessionStates) expire(now time.Time) {
+	for session, tracked := range s.states {
+		if session == "" || now.Sub(tracked.lastSeen) < sessionIdleTimeout {
+			continue
+		}
+		log.Debugf("Forgetting game session %v of %v, it went silent.", session, tracked.state.snapshot().CharacterName)
+		tracked.state.stop()
+		delete(s.states, session)
+	}
+}
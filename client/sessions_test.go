@@ -0,0 +1,85 @@
+package client
+
+import (
+	"testing"
+	"time"
+)
+
+func newTestSessions(t *testing.T) *sessionStates {
+	t.Helper()
+
+	s := newSessionStates()
+	t.Cleanup(func() {
+		for _, tracked := range s.states {
+			tracked.state.stop()
+		}
+	})
+	return s
+}
+
+func TestSessionsAreSeparate(t *testing.T) {
+	s := newTestSessions(t)
+
+	first := s.get("udp:1")
+	second := s.get("udp:2")
+	first.update(func(snapshot *stateSnapshot) { snapshot.CharacterName = "First" })
+	second.update(func(snapshot *stateSnapshot) { snapshot.CharacterName = "Second" })
+
+	if got := s.get("udp:1"); got != first || got.snapshot().CharacterName != "First" {
+		t.Errorf("session udp:1 has %q, want First", got.snapshot().CharacterName)
+	}
+	if got := s.get("udp:2").snapshot(); got.CharacterName != "Second" || got.Session != "udp:2" {
+		t.Errorf("session udp:2 has %q in %q, want Second", got.CharacterName, got.Session)
+	}
+}
+
+// The login server names the game server before the game connection is
+// there, a new session starts from it
+func TestNewSessionTakesTheLoginHandshake(t *testing.T) {
+	s := newTestSessions(t)
+
+	s.get("").announceGameServer("5.188.125.40", "login")
+	game := s.get("udp:55123").snapshot()
+	if game.AnnouncedServerIP != "5.188.125.40" {
+		t.Fatalf("the new session has the announced server %q, want the one of the login", game.AnnouncedServerIP)
+	}
+	if r, ok := game.realm(); !ok || r.Name != "west" {
+		t.Errorf("the new session is in realm %q, want west", r.Name)
+	}
+
+	// only copied when the session starts
+	s.get("").announceGameServer("5.45.187.40", "login")
+	if got := s.get("udp:55123").snapshot().AnnouncedServerIP; got != "5.188.125.40" {
+		t.Errorf("a later login changed the running session to %q", got)
+	}
+	if got := s.get("udp:55124").snapshot().AnnouncedServerIP; got != "5.45.187.40" {
+		t.Errorf("the next session has the announced server %q, want the one of the last login", got)
+	}
+}
+
+func TestSilentSessionsExpire(t *testing.T) {
+	s := newTestSessions(t)
+
+	s.get("")
+	s.get("udp:1")
+	s.get("udp:2")
+	s.mu.Lock()
+	for _, session := range []string{"", "udp:1"} {
+		s.states[session].lastSeen = time.Now().Add(-2 * sessionIdleTimeout)
+	}
+	s.mu.Unlock()
+
+	// a new session looks for the silent ones
+	s.get("udp:3")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.states["udp:1"]; ok {
+		t.Error("the silent session was kept")
+	}
+	for _, session := range []string{"", "udp:2", "udp:3"} {
+		if _, ok := s.states[session]; !ok {
+			t.Errorf("session %q was dropped", session)
+		}
+	}
+}
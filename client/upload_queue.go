@@ -0,0 +1,471 @@
+package client
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ao-data/albiondata-client/log"
+)
+
+const (
+	queueDirName = "albiondata-client.queue"
+
+	// How long a payload is retried before it is dropped. Market data older
+	// than this is not worth sending anymore.
+	defaultUploadMaxAge = time.Hour
+
+	// Payloads waiting per target, the oldest are dropped beyond that
+	uploadQueueLimit = 10000
+
+	// Number of uploads a target runs at the same time
+	uploadSenders = workerCount
+
+	// Backoff between retries after a failed upload
+	minUploadBackoff = time.Second
+	maxUploadBackoff = 5 * time.Minute
+)
+
+// uploadError is a failed upload. It is retried unless permanent, not
+// before retryAfter if the ingest asked for a pause.
+type uploadError struct {
+	err        error
+	retryAfter time.Duration
+	permanent  bool
+}
+
+func (e *uploadError) Error() string {
+	return e.err.Error()
+}
+
+func (e *uploadError) Unwrap() error {
+	return e.err
+}
+
+// permanentError marks an error as one retrying cannot fix, the payload is
+// dropped
+func permanentError(err error) error {
+	return &uploadError{err: err, permanent: true}
+}
+
+// httpUploadError turns a bad response into an uploadError. 429 and 5xx are
+// retried and honor Retry-After, other codes mean the payload is refused.
+func httpUploadError(resp *http.Response, body string) error {
+	err := &uploadError{err: fmt.Errorf("got bad response code: %v (%v)", resp.StatusCode, strings.TrimSpace(body))}
+
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+		err.retryAfter = parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+	default:
+		err.permanent = true
+	}
+	return err
+}
+
+// parseRetryAfter reads a Retry-After header, given in seconds or as a date
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil && date.After(now) {
+		return date.Sub(now)
+	}
+	return 0
+}
+
+// queuedUpload is a payload waiting to be sent to one target
+type queuedUpload struct {
+	Body       []byte        `json:"Body"`
+	Topic      string        `json:"Topic"`
+	State      stateSnapshot `json:"State"`
+	Identifier string        `json:"Identifier"`
+	QueuedAt   time.Time     `json:"QueuedAt"`
+
+	// name of the file holding the upload, empty when it is not on disk
+	file     string
+	attempts int
+}
+
+type uploadQueueStats struct {
+	queued    atomic.Int64
+	delivered atomic.Int64
+	retried   atomic.Int64
+	expired   atomic.Int64
+	rejected  atomic.Int64
+	overflow  atomic.Int64
+}
+
+// uploadQueue sends the payloads for one target. The payloads are kept on
+// disk until they are delivered, so they survive a restart, and failed
+// uploads are retried with an exponential backoff until they are too old.
+type uploadQueue struct {
+	target   string
+	uploader uploader
+	dir      string
+	maxAge   time.Duration
+	limit    int
+	// the topics sent to the target, all when empty
+	topics map[string]bool
+
+	mu       sync.Mutex
+	pending  []*queuedUpload
+	inFlight int
+	retryAt  time.Time
+	backoff  time.Duration
+
+	sequence atomic.Int64
+
+	wake  chan struct{}
+	quit  chan struct{}
+	done  sync.WaitGroup
+	stats uploadQueueStats
+}
+
+// newUploadQueue starts sending to u. Payloads are saved in dir if it is not
+// empty, the ones left there by a previous run are sent first.
+func newUploadQueue(target string, u uploader, dir string, maxAge time.Duration) *uploadQueue {
+	q := &uploadQueue{
+		target:   target,
+		uploader: u,
+		dir:      dir,
+		maxAge:   maxAge,
+		limit:    uploadQueueLimit,
+		backoff:  minUploadBackoff,
+		wake:     make(chan struct{}, uploadSenders),
+		quit:     make(chan struct{}),
+	}
+
+	if dir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			log.Errorf("Could not create the upload queue for %v, uploads will not survive a restart: %v", redactTarget(target), err)
+			q.dir = ""
+		} else {
+			q.load()
+		}
+	}
+
+	for i := 0; i < uploadSenders; i++ {
+		q.done.Add(1)
+		go q.send()
+	}
+
+	return q
+}
+
+// queueDir returns where the payloads of a target are kept. The target is
+// hashed, it may contain credentials.
+func queueDir(base string, target string) string {
+	if base == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(target))
+	return filepath.Join(base, hex.EncodeToString(sum[:8]))
+}
+
+// redactTarget hides the credentials of a target for logs
+func redactTarget(target string) string {
+	parsed, err := url.Parse(target)
+	if err != nil {
+		return target
+	}
+	if parsed.User != nil {
+		parsed.User = url.User("redacted")
+	}
+
+	query := parsed.Query()
+	// chat webhooks carry their token in the path
+	if query.Has("format") && strings.Trim(parsed.Path, "/") != "" {
+		parsed.Path = "/redacted"
+		parsed.RawPath = ""
+	}
+
+	redacted := false
+	for _, option := range secretOptions {
+		if query.Has(option) {
+			query.Set(option, "redacted")
+			redacted = true
+		}
+	}
+	if redacted {
+		parsed.RawQuery = query.Encode()
+	}
+	return parsed.String()
+}
+
+// load queues the payloads a previous run left on disk
+func (q *uploadQueue) load() {
+	entries, err := os.ReadDir(q.dir)
+	if err != nil {
+		log.Errorf("Could not read the upload queue for %v: %v", redactTarget(q.target), err)
+		return
+	}
+
+	// the names start with the time they were queued
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+
+	now := time.Now()
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		path := filepath.Join(q.dir, entry.Name())
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			log.Warnf("Could not read queued upload %v: %v", path, err)
+			continue
+		}
+		item := &queuedUpload{}
+		if err := json.Unmarshal(data, item); err != nil {
+			log.Warnf("Dropping unreadable queued upload %v: %v", path, err)
+			os.Remove(path)
+			continue
+		}
+		item.file = entry.Name()
+
+		if now.Sub(item.QueuedAt) > q.maxAge {
+			q.stats.expired.Add(1)
+			os.Remove(path)
+			continue
+		}
+		q.pending = append(q.pending, item)
+	}
+
+	if len(q.pending) > 0 {
+		log.Infof("Resuming %d uploads to %v left from the previous run.", len(q.pending), redactTarget(q.target))
+	}
+}
+
+// push queues a payload and wakes a sender
+func (q *uploadQueue) push(body []byte, topic string, state stateSnapshot, identifier string) {
+	if len(q.topics) > 0 && !q.topics[topic] {
+		return
+	}
+
+	item := &queuedUpload{
+		Body:       body,
+		Topic:      topic,
+		State:      state,
+		Identifier: identifier,
+		QueuedAt:   time.Now(),
+	}
+
+	// on disk before a sender can see it, so a delivered upload is never
+	// written back
+	if q.dir != "" {
+		item.file = fmt.Sprintf("%020d-%06d.json", item.QueuedAt.UnixNano(), q.sequence.Add(1)%1000000)
+		q.save(item)
+	}
+
+	q.mu.Lock()
+	q.pending = append(q.pending, item)
+	var dropped []*queuedUpload
+	if len(q.pending) > q.limit {
+		dropped = q.pending[:len(q.pending)-q.limit]
+		q.pending = q.pending[len(q.pending)-q.limit:]
+	}
+	q.mu.Unlock()
+
+	q.stats.queued.Add(1)
+	for _, old := range dropped {
+		q.stats.overflow.Add(1)
+		q.remove(old)
+	}
+	if len(dropped) > 0 {
+		log.Warnf("The upload queue for %v is full, dropped the %d oldest uploads.", redactTarget(q.target), len(dropped))
+	}
+
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+}
+
+// save writes a payload next to its final name and renames it, so a crash
+// never leaves half a file
+func (q *uploadQueue) save(item *queuedUpload) {
+	data, err := json.Marshal(item)
+	if err != nil {
+		log.Errorf("Could not encode upload %v: %v", item.Identifier, err)
+		return
+	}
+
+	path := filepath.Join(q.dir, item.file)
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		log.Errorf("Could not save upload %v, it will not survive a restart: %v", item.Identifier, err)
+		return
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		log.Errorf("Could not save upload %v, it will not survive a restart: %v", item.Identifier, err)
+	}
+}
+
+func (q *uploadQueue) remove(item *queuedUpload) {
+	if item.file == "" {
+		return
+	}
+	if err := os.Remove(filepath.Join(q.dir, item.file)); err != nil && !os.IsNotExist(err) {
+		log.Warnf("Could not remove delivered upload %v: %v", item.file, err)
+	}
+}
+
+// next takes the oldest payload, dropping the expired ones. Without one
+// ready, it returns how long to wait, 0 meaning until woken.
+func (q *uploadQueue) next(now time.Time) (*queuedUpload, time.Duration) {
+	item, wait, expired := q.take(now)
+
+	// the files are removed without the lock, like push saves them
+	for _, e := range expired {
+		log.Warnf("Dropping upload %v to %v, it could not be sent within %v.", e.Identifier, redactTarget(q.target), q.maxAge)
+		q.remove(e)
+	}
+	return item, wait
+}
+
+// take is next under the lock, it returns the expired payloads it dropped
+func (q *uploadQueue) take(now time.Time) (*queuedUpload, time.Duration, []*queuedUpload) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	var expired []*queuedUpload
+	for len(q.pending) > 0 && now.Sub(q.pending[0].QueuedAt) > q.maxAge {
+		expired = append(expired, q.pending[0])
+		q.pending = q.pending[1:]
+		q.stats.expired.Add(1)
+	}
+
+	if now.Before(q.retryAt) {
+		return nil, q.retryAt.Sub(now), expired
+	}
+	if len(q.pending) == 0 {
+		return nil, 0, expired
+	}
+
+	item := q.pending[0]
+	q.pending = q.pending[1:]
+	q.inFlight++
+	return item, 0, expired
+}
+
+// idle tells whether everything queued was sent or dropped
+func (q *uploadQueue) idle() bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.pending) == 0 && q.inFlight == 0
+}
+
+// send delivers payloads until the queue is closed
+func (q *uploadQueue) send() {
+	defer q.done.Done()
+
+	for {
+		item, wait := q.next(time.Now())
+		if item == nil {
+			var timer <-chan time.Time
+			if wait > 0 {
+				timer = time.After(wait)
+			}
+			select {
+			case <-q.quit:
+				return
+			case <-q.wake:
+			case <-timer:
+			}
+			continue
+		}
+
+		q.deliver(item)
+	}
+}
+
+func (q *uploadQueue) deliver(item *queuedUpload) {
+	state := item.State
+	state.CapturedAt = item.QueuedAt
+	err := q.uploader.sendToIngest(item.Body, item.Topic, state, item.Identifier)
+	if err == nil {
+		q.stats.delivered.Add(1)
+		q.remove(item)
+
+		q.mu.Lock()
+		q.inFlight--
+		q.backoff = minUploadBackoff
+		q.mu.Unlock()
+		return
+	}
+
+	var failure *uploadError
+	ok := errors.As(err, &failure)
+	if ok && failure.permanent {
+		q.stats.rejected.Add(1)
+		log.Errorf("Upload %v to %v was refused, dropping it: %v", item.Identifier, redactTarget(q.target), err)
+		q.remove(item)
+
+		q.mu.Lock()
+		q.inFlight--
+		q.mu.Unlock()
+		return
+	}
+
+	item.attempts++
+	q.stats.retried.Add(1)
+
+	q.mu.Lock()
+	q.inFlight--
+	wait := q.backoff
+	if ok && failure.retryAfter > wait {
+		wait = failure.retryAfter
+	}
+	q.retryAt = time.Now().Add(wait)
+	q.backoff = min(q.backoff*2, maxUploadBackoff)
+	// back in front, it is still the oldest
+	q.pending = append([]*queuedUpload{item}, q.pending...)
+	q.mu.Unlock()
+
+	log.Errorf("Upload %v to %v failed (attempt %d), retrying in %v: %v", item.Identifier, redactTarget(q.target), item.attempts, wait.Round(time.Second), err)
+}
+
+// close stops sending. Payloads on disk are sent by the next run, the others
+// are lost.
+func (q *uploadQueue) close() {
+	close(q.quit)
+	q.done.Wait()
+
+	if c, ok := q.uploader.(closingUploader); ok {
+		c.close()
+	}
+
+	q.mu.Lock()
+	pending := len(q.pending)
+	q.mu.Unlock()
+
+	if pending > 0 && q.dir == "" {
+		log.Warnf("Dropping %d uploads to %v that were not sent yet.", pending, redactTarget(q.target))
+	}
+}
+
+func (q *uploadQueue) logStats() {
+	q.mu.Lock()
+	pending := len(q.pending)
+	q.mu.Unlock()
+
+	log.Debugf("Uploads to %v: pending %d, queued %d, delivered %d, retried %d, dropped %d (expired %d, refused %d, overflow %d)",
+		redactTarget(q.target), pending, q.stats.queued.Load(), q.stats.delivered.Load(), q.stats.retried.Load(),
+		q.stats.expired.Load()+q.stats.rejected.Load()+q.stats.overflow.Load(),
+		q.stats.expired.Load(), q.stats.rejected.Load(), q.stats.overflow.Load())
+}
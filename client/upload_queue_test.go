@@ -0,0 +1,120 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// failingUploader fails every upload with err
+type failingUploader struct {
+	err   error
+	calls atomic.Int64
+}
+
+func (u *failingUploader) sendToIngest(body []byte, topic string, state stateSnapshot, identifier string) error {
+	u.calls.Add(1)
+	return u.err
+}
+
+func waitForStat(t *testing.T, stat *atomic.Int64, want int64) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for stat.Load() < want {
+		if time.Now().After(deadline) {
+			t.Fatalf("stat is %d, want %d", stat.Load(), want)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestUploadQueueDropsPermanentErrors(t *testing.T) {
+	// wrapped, as uploaders add context to the errors they return
+	u := &failingUploader{err: fmt.Errorf("while sending: %w", permanentError(errors.New("refused")))}
+	q := newUploadQueue("test://permanent", u, "", time.Hour)
+	defer q.close()
+
+	q.push([]byte("{}"), "topic", stateSnapshot{}, "id")
+	waitForStat(t, &q.stats.rejected, 1)
+
+	if retried := q.stats.retried.Load(); retried != 0 {
+		t.Errorf("a permanent error was retried %d times", retried)
+	}
+	if calls := u.calls.Load(); calls != 1 {
+		t.Errorf("sent %d times, want 1", calls)
+	}
+}
+
+func TestUploadQueueRetriesOtherErrors(t *testing.T) {
+	u := &failingUploader{err: errors.New("connection reset")}
+	q := newUploadQueue("test://temporary", u, "", time.Hour)
+	defer q.close()
+
+	q.push([]byte("{}"), "topic", stateSnapshot{}, "id")
+	waitForStat(t, &q.stats.retried, 1)
+
+	if rejected := q.stats.rejected.Load(); rejected != 0 {
+		t.Errorf("a temporary error dropped %d payloads", rejected)
+	}
+}
+
+func TestUploadQueueDropsExpired(t *testing.T) {
+	dir := t.TempDir()
+	u := &failingUploader{err: errors.New("connection reset")}
+	q := newUploadQueue("test://expired", u, dir, time.Hour)
+	defer q.close()
+
+	q.push([]byte("{}"), "topic", stateSnapshot{}, "id")
+	waitForStat(t, &q.stats.retried, 1)
+
+	if item, _ := q.next(time.Now().Add(2 * time.Hour)); item != nil {
+		t.Fatalf("got the upload %v past its age", item.Identifier)
+	}
+	if expired := q.stats.expired.Load(); expired != 1 {
+		t.Errorf("%d uploads expired, want 1", expired)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+		t.Errorf("the expired upload was left in the queue directory: %v", entries)
+	}
+}
+
+func TestHTTPUploadersHaveATimeout(t *testing.T) {
+	for _, raw := range []string{"http://127.0.0.1:1", "http+pow://127.0.0.1:1"} {
+		target := &uploaderTarget{URL: mustParseURL(t, raw)}
+		u, err := uploaderFactories[target.URL.Scheme](target)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var timeout time.Duration
+		switch u := u.(type) {
+		case *httpUploader:
+			timeout = u.client.Timeout
+		case *httpUploaderPow:
+			timeout = u.client.Timeout
+			u.close()
+		}
+		if timeout != defaultHTTPTimeout {
+			t.Errorf("%v: timeout %v, want %v", raw, timeout, defaultHTTPTimeout)
+		}
+	}
+
+	target := &uploaderTarget{URL: mustParseURL(t, "http://127.0.0.1:1"), Timeout: 5 * time.Second}
+	u, _ := newHTTPUploader(target)
+	if timeout := u.(*httpUploader).client.Timeout; timeout != 5*time.Second {
+		t.Errorf("timeout %v, want the 5s of the target", timeout)
+	}
+}
+
+func mustParseURL(t *testing.T, raw string) *url.URL {
+	t.Helper()
+	u, err := url.Parse(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return u
+}
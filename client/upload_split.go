@@ -0,0 +1,85 @@
+package client
+
+import (
+	"encoding/json"
+
+	"github.com/ao-data/albiondata-client/lib"
+	"github.com/ao-data/albiondata-client/log"
+	uuid "github.com/nu7hatch/gouuid"
+)
+
+// uploadPart is one payload of a split upload
+type uploadPart struct {
+	data       []byte
+	identifier string
+}
+
+// splitUpload splits a market upload whose JSON is larger than maxSize into
+// several uploads at order boundaries, as a big market search serializes
+// into more than the ingest accepts at once. The first part keeps the
+// identifier, the others get fresh ones so the ingest does not take them
+// for duplicates. Other uploads are never split.
+func splitUpload(upload interface{}, data []byte, identifier string, maxSize int) []uploadPart {
+	whole := []uploadPart{{data, identifier}}
+	if maxSize <= 0 || len(data) <= maxSize {
+		return whole
+	}
+
+	var orders []*lib.MarketOrder
+	switch u := upload.(type) {
+	case lib.MarketUpload:
+		orders = u.Orders
+	case *lib.MarketUpload:
+		orders = u.Orders
+	default:
+		log.Warnf("Sending a %d byte upload whole, only market uploads can be split", len(data))
+		return whole
+	}
+
+	// {"Orders":[...]} around the orders, which are separated by commas
+	overhead := len(`{"Orders":[]}`)
+	var chunks [][]*lib.MarketOrder
+	var chunk []*lib.MarketOrder
+	size := overhead
+	for _, order := range orders {
+		encoded, err := json.Marshal(order)
+		if err != nil {
+			log.Errorf("Error while marshalling a market order: %v", err)
+			return whole
+		}
+		orderSize := len(encoded)
+		if len(chunk) > 0 {
+			orderSize++
+		}
+		if len(chunk) > 0 && size+orderSize > maxSize {
+			chunks = append(chunks, chunk)
+			chunk, size, orderSize = nil, overhead, len(encoded)
+		}
+		if overhead+len(encoded) > maxSize {
+			log.Warnf("Market order %v alone is larger than %d bytes, it is sent in an upload of its own", order.ID, maxSize)
+		}
+		chunk = append(chunk, order)
+		size += orderSize
+	}
+	chunks = append(chunks, chunk)
+	if len(chunks) == 1 {
+		return whole
+	}
+
+	parts := make([]uploadPart, 0, len(chunks))
+	for i, chunk := range chunks {
+		data, err := json.Marshal(lib.MarketUpload{Orders: chunk})
+		if err != nil {
+			log.Errorf("Error while marshalling a part of a market upload: %v", err)
+			return whole
+		}
+		part := uploadPart{data, identifier}
+		if i > 0 {
+			id, _ := uuid.NewV4()
+			part.identifier = id.String()
+		}
+		parts = append(parts, part)
+	}
+	log.Infof("Split a %d byte market upload of %d orders into %d uploads (Identifier: %s)", len(data), len(orders), len(parts), identifier)
+	return parts
+}
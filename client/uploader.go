@@ -1,5 +1,86 @@
 package client
 
+import (
+	"time"
+
+	"github.com/ao-data/albiondata-client/lib"
+	"github.com/ao-data/albiondata-client/log"
+)
+
+// uploader sends a payload to an ingest. A failed upload returns an error and
+// is retried, unless the error is wrapped by permanentError. An *uploadError
+// may also say when to retry. Uploaders are long-lived and used by several
+// goroutines at once.
 type uploader interface {
-	sendToIngest(body []byte, topic string, state *albionState, identifier string)
+	sendToIngest(body []byte, topic string, state stateSnapshot, identifier string) error
+}
+
+// closingUploader is implemented by uploaders holding connections, they are
+// closed at shutdown
+type closingUploader interface {
+	close()
+}
+
+// Uploader is an uploader added by code outside of this package through
+// RegisterUploader. A failed upload is retried unless its error is wrapped by
+// PermanentError.
+type Uploader interface {
+	SendToIngest(body []byte, topic string, state UploadState, identifier string) error
+}
+
+// UploaderCloser is implemented by Uploaders holding connections, they are
+// closed at shutdown
+type UploaderCloser interface {
+	Close() error
+}
+
+// UploadState is what the client knew about the player when the payload was
+// captured
+type UploadState struct {
+	Location      lib.Location
+	CharacterID   lib.CharacterID
+	CharacterName string
+	// The realm of the game server, 0 and empty when it is not known
+	ServerID  int
+	RealmName string
+	// When the payload was captured, a retried upload goes out later
+	CapturedAt time.Time
+}
+
+func newUploadState(state stateSnapshot) UploadState {
+	upload := UploadState{
+		Location:      state.Location,
+		CharacterID:   state.CharacterId,
+		CharacterName: state.CharacterName,
+		ServerID:      state.AODataServerID,
+		CapturedAt:    state.CapturedAt,
+	}
+	if r, ok := state.realm(); ok {
+		upload.ServerID = r.ID
+		upload.RealmName = r.Name
+	}
+	return upload
+}
+
+// PermanentError marks an error of an Uploader as one retrying cannot fix,
+// the payload is dropped
+func PermanentError(err error) error {
+	return permanentError(err)
+}
+
+// externalUploader runs a registered Uploader as one of ours
+type externalUploader struct {
+	u Uploader
+}
+
+func (e externalUploader) sendToIngest(body []byte, topic string, state stateSnapshot, identifier string) error {
+	return e.u.SendToIngest(body, topic, newUploadState(state), identifier)
+}
+
+func (e externalUploader) close() {
+	if c, ok := e.u.(UploaderCloser); ok {
+		if err := c.Close(); err != nil {
+			log.Errorf("Could not close an uploader: %v", err)
+		}
+	}
 }
@@ -0,0 +1,347 @@
+package client
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ao-data/albiondata-client/lib"
+	"github.com/ao-data/albiondata-client/log"
+)
+
+func init() {
+	registerUploader("file", newFileUploader)
+}
+
+const (
+	defaultFileMaxSize = 100 << 20
+	defaultFileRotate  = 24 * time.Hour
+	fileSinkExt        = ".jsonl"
+)
+
+// fileEnvelope is a line of a file sink, the payload with where and when it
+// was captured
+type fileEnvelope struct {
+	Timestamp     time.Time       `json:"Timestamp"`
+	Topic         string          `json:"Topic"`
+	Realm         string          `json:"Realm,omitempty"`
+	ServerID      int             `json:"ServerId,omitempty"`
+	Location      lib.Location    `json:"Location"`
+	CharacterID   lib.CharacterID `json:"CharacterId,omitempty"`
+	CharacterName string          `json:"CharacterName,omitempty"`
+	Identifier    string          `json:"Identifier"`
+	Payload       json.RawMessage `json:"Payload"`
+}
+
+// fileUploader appends the payloads to a JSON Lines file per topic in a
+// directory, rotated by size and time:
+//
+//	file:///var/lib/albiondata?max-size=50MB&rotate=1h&gzip=true
+//
+// The current file of a topic is <topic>.jsonl, rotated ones get the time
+// they were rotated at in their name. A max-size or rotate of 0 turns that
+// rotation off.
+type fileUploader struct {
+	dir     string
+	maxSize int64
+	rotate  time.Duration
+	gzip    bool
+
+	mu    sync.Mutex
+	files map[string]*sinkFile
+
+	// compressions of rotated files still running
+	compressing sync.WaitGroup
+}
+
+// sinkFile is the open current file of a topic
+type sinkFile struct {
+	file   *os.File
+	size   int64
+	period time.Time
+}
+
+func newFileUploader(target *uploaderTarget) (uploader, error) {
+	u := &fileUploader{
+		dir:     fileTargetPath(target),
+		maxSize: defaultFileMaxSize,
+		rotate:  defaultFileRotate,
+		files:   make(map[string]*sinkFile),
+	}
+	if u.dir == "" {
+		return nil, fmt.Errorf("no directory")
+	}
+
+	query := target.URL.Query()
+	if value := query.Get("max-size"); value != "" {
+		size, err := parseByteSize(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid max-size: %v", err)
+		}
+		u.maxSize = size
+	}
+	if value := query.Get("rotate"); value != "" {
+		rotate, err := time.ParseDuration(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rotate: %v", err)
+		}
+		u.rotate = rotate
+	}
+	if value := query.Get("gzip"); value != "" {
+		gzip, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid gzip: %v", err)
+		}
+		u.gzip = gzip
+	}
+
+	if err := os.MkdirAll(u.dir, 0755); err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+// fileTargetPath is the directory of file:///abs/dir, file://rel/dir or
+// file:rel/dir. Windows paths are given as file:///C:/dir.
+func fileTargetPath(target *uploaderTarget) string {
+	if target.URL.Opaque != "" {
+		return filepath.FromSlash(target.URL.Opaque)
+	}
+	path := target.URL.Host + target.URL.Path
+	if len(path) >= 3 && path[0] == '/' && path[2] == ':' && isDriveLetter(path[1]) {
+		path = path[1:]
+	}
+	return filepath.FromSlash(path)
+}
+
+func isDriveLetter(c byte) bool {
+	return ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z')
+}
+
+// parseByteSize reads sizes like 1048576, 512KB, 100MB or 2GB
+func parseByteSize(value string) (int64, error) {
+	units := []struct {
+		suffix string
+		size   int64
+	}{{"GB", 1 << 30}, {"MB", 1 << 20}, {"KB", 1 << 10}, {"B", 1}}
+
+	value = strings.ToUpper(strings.TrimSpace(value))
+	multiplier := int64(1)
+	for _, unit := range units {
+		if strings.HasSuffix(value, unit.suffix) {
+			value = strings.TrimSpace(strings.TrimSuffix(value, unit.suffix))
+			multiplier = unit.size
+			break
+		}
+	}
+
+	size, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || size < 0 {
+		return 0, fmt.Errorf("%q is not a size", value)
+	}
+	return size * multiplier, nil
+}
+
+func (u *fileUploader) sendToIngest(body []byte, topic string, state stateSnapshot, identifier string) error {
+	captured := state.CapturedAt
+	if captured.IsZero() {
+		captured = time.Now()
+	}
+	envelope := fileEnvelope{
+		Timestamp:     captured.UTC(),
+		Topic:         topic,
+		Location:      state.Location,
+		CharacterID:   state.CharacterId,
+		CharacterName: state.CharacterName,
+		Identifier:    identifier,
+		Payload:       body,
+	}
+	if r, ok := state.realm(); ok {
+		envelope.Realm = r.Name
+		envelope.ServerID = r.ID
+	}
+
+	line, err := json.Marshal(envelope)
+	if err != nil {
+		return permanentError(fmt.Errorf("could not encode %v payload: %v", topic, err))
+	}
+	line = append(line, '\n')
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	f, err := u.current(topic, envelope.Timestamp, int64(len(line)))
+	if err != nil {
+		return err
+	}
+	n, err := f.file.Write(line)
+	f.size += int64(n)
+	if err != nil {
+		return fmt.Errorf("could not write to %v: %v", f.file.Name(), err)
+	}
+	return nil
+}
+
+// current returns the file of a topic to append the next line to, rotating
+// the open one if it is full or of an earlier period. Lines captured in an
+// earlier period than the open file, like retried ones, go into it.
+func (u *fileUploader) current(topic string, now time.Time, next int64) (*sinkFile, error) {
+	period := u.period(now)
+
+	f := u.files[topic]
+	if f != nil && (f.period.Before(period) || u.full(f, next)) {
+		u.rotateFile(topic, f, now)
+		f = nil
+	}
+	if f != nil {
+		return f, nil
+	}
+
+	f, err := u.open(topic, period)
+	if err != nil {
+		return nil, err
+	}
+	// a file left from an earlier run is rotated first if its period is over
+	if f.period.Before(period) || u.full(f, next) {
+		u.rotateFile(topic, f, now)
+		if f, err = u.open(topic, period); err != nil {
+			return nil, err
+		}
+		// appended to even if the rotation failed
+		f.period = period
+	}
+	u.files[topic] = f
+	return f, nil
+}
+
+// open opens the current file of a topic, a file that has lines already
+// belongs to the period it was last written in
+func (u *fileUploader) open(topic string, period time.Time) (*sinkFile, error) {
+	path := filepath.Join(u.dir, topic+fileSinkExt)
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	f := &sinkFile{file: file, size: info.Size(), period: period}
+	if info.Size() > 0 {
+		f.period = u.period(info.ModTime())
+	}
+	return f, nil
+}
+
+// full tells whether the next line would make a file larger than allowed,
+// a file always takes at least one line
+func (u *fileUploader) full(f *sinkFile, next int64) bool {
+	return u.maxSize > 0 && f.size > 0 && f.size+next > u.maxSize
+}
+
+// period is the start of the rotation period a time is in
+func (u *fileUploader) period(t time.Time) time.Time {
+	if u.rotate <= 0 {
+		return time.Time{}
+	}
+	return t.UTC().Truncate(u.rotate)
+}
+
+// rotateFile closes the current file of a topic and renames it after the
+// rotation time, compressing it in the background if asked to
+func (u *fileUploader) rotateFile(topic string, f *sinkFile, now time.Time) {
+	delete(u.files, topic)
+
+	current := f.file.Name()
+	if err := f.file.Close(); err != nil {
+		log.Errorf("Could not close %v: %v", current, err)
+	}
+
+	rotated := u.rotatedName(topic, now)
+	if err := os.Rename(current, rotated); err != nil {
+		log.Errorf("Could not rotate %v: %v", current, err)
+		return
+	}
+	log.Debugf("Rotated %v to %v", current, rotated)
+
+	if u.gzip {
+		u.compressing.Add(1)
+		go func() {
+			defer u.compressing.Done()
+			if err := gzipFile(rotated); err != nil {
+				log.Errorf("Could not compress %v: %v", rotated, err)
+			}
+		}()
+	}
+}
+
+// rotatedName is a free name for a rotated file of a topic
+func (u *fileUploader) rotatedName(topic string, now time.Time) string {
+	base := filepath.Join(u.dir, topic+"-"+now.UTC().Format("20060102T150405"))
+	name := base + fileSinkExt
+	for i := 1; fileExists(name) || fileExists(name+".gz"); i++ {
+		name = fmt.Sprintf("%v-%d%v", base, i, fileSinkExt)
+	}
+	return name
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// gzipFile replaces a file by its gzipped copy
+func gzipFile(path string) error {
+	in, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.Create(path + ".gz.tmp")
+	if err != nil {
+		return err
+	}
+
+	zw := gzip.NewWriter(out)
+	zw.Name = filepath.Base(path)
+	_, err = io.Copy(zw, in)
+	if err == nil {
+		err = zw.Close()
+	}
+	if closeErr := out.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(path+".gz.tmp", path+".gz")
+	}
+	if err != nil {
+		os.Remove(path + ".gz.tmp")
+		return err
+	}
+
+	in.Close()
+	return os.Remove(path)
+}
+
+func (u *fileUploader) close() {
+	u.mu.Lock()
+	for topic, f := range u.files {
+		if err := f.file.Close(); err != nil {
+			log.Errorf("Could not close %v: %v", f.file.Name(), err)
+		}
+		delete(u.files, topic)
+	}
+	u.mu.Unlock()
+
+	u.compressing.Wait()
+}
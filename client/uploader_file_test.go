@@ -0,0 +1,113 @@
+package client
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ao-data/albiondata-client/lib"
+)
+
+func TestFileTargetPath(t *testing.T) {
+	tests := map[string]string{
+		"file:///var/lib/albiondata": "/var/lib/albiondata",
+		"file://data/albion":         "data/albion",
+		"file:data/albion":           "data/albion",
+		"file:///C:/data":            "C:/data",
+		"file:///c:/Albion Data":     "c:/Albion Data",
+		"file://C:/data":             "C:/data",
+	}
+	for raw, want := range tests {
+		target, err := parseTarget(raw)
+		if err != nil {
+			t.Fatalf("%v: %v", raw, err)
+		}
+		if got := fileTargetPath(target); got != filepath.FromSlash(want) {
+			t.Errorf("fileTargetPath(%v) = %q, want %q", raw, got, filepath.FromSlash(want))
+		}
+	}
+}
+
+func readEnvelopes(t *testing.T, path string) []fileEnvelope {
+	t.Helper()
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	var envelopes []fileEnvelope
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var e fileEnvelope
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			t.Fatal(err)
+		}
+		envelopes = append(envelopes, e)
+	}
+	return envelopes
+}
+
+// A retried or resumed upload keeps the time it was captured at
+func TestFileUploaderUsesTheCaptureTime(t *testing.T) {
+	dir := t.TempDir()
+	u, _, err := createUploader("file://" + filepath.ToSlash(dir) + "?rotate=1h")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// left on disk by the previous run
+	queueDir := t.TempDir()
+	captured := time.Now().Add(-3 * time.Hour).UTC().Truncate(time.Second)
+	data, _ := json.Marshal(&queuedUpload{Body: goldPrices, Topic: lib.NatsGoldPricesIngest, Identifier: "resumed", QueuedAt: captured})
+	if err := os.WriteFile(filepath.Join(queueDir, "00000000000000000001-000001.json"), data, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	q := newUploadQueue("file", u, queueDir, 24*time.Hour)
+	waitForStat(t, &q.stats.delivered, 1)
+	q.close()
+
+	envelopes := readEnvelopes(t, filepath.Join(dir, lib.NatsGoldPricesIngest+fileSinkExt))
+	if len(envelopes) != 1 {
+		t.Fatalf("wrote %d lines, want 1", len(envelopes))
+	}
+	if !envelopes[0].Timestamp.Equal(captured) {
+		t.Errorf("Timestamp = %v, want the capture time %v", envelopes[0].Timestamp, captured)
+	}
+}
+
+func TestFileUploaderRotatesByCaptureTime(t *testing.T) {
+	dir := t.TempDir()
+	u, _, err := createUploader("file://" + filepath.ToSlash(dir) + "?rotate=1h")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer u.(*fileUploader).close()
+
+	hour := time.Now().UTC().Truncate(time.Hour)
+	send := func(at time.Time, identifier string) {
+		t.Helper()
+		if err := u.sendToIngest(goldPrices, lib.NatsGoldPricesIngest, stateSnapshot{CapturedAt: at}, identifier); err != nil {
+			t.Fatal(err)
+		}
+	}
+	send(hour.Add(-90*time.Minute), "old")
+	send(hour.Add(-30*time.Minute), "previous hour")
+	// a late retry from before does not rotate back
+	send(hour.Add(-80*time.Minute), "retried")
+	send(hour.Add(time.Minute), "current")
+
+	rotated, _ := filepath.Glob(filepath.Join(dir, lib.NatsGoldPricesIngest+"-*"+fileSinkExt))
+	if len(rotated) != 2 {
+		t.Fatalf("rotated %d files, want 2: %v", len(rotated), rotated)
+	}
+	current := readEnvelopes(t, filepath.Join(dir, lib.NatsGoldPricesIngest+fileSinkExt))
+	if len(current) != 1 || current[0].Identifier != "current" {
+		t.Errorf("the current file has %v, want only the current upload", current)
+	}
+}
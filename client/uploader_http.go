@@ -2,56 +2,131 @@ package client
 
 import (
 	"bytes"
+	"errors"
+	"fmt"
 	"io"
 	"io/ioutil"
 	"net/http"
+	"net/url"
+	"strings"
+	"time"
 
 	"github.com/ao-data/albiondata-client/log"
 )
 
+func init() {
+	registerUploader("http", newHTTPUploader)
+	registerUploader("https", newHTTPUploader)
+}
+
+// How long an HTTP upload may take when the target sets no timeout, so a
+// hung connection does not hold a sender of the queue forever
+const defaultHTTPTimeout = 30 * time.Second
+
+// httpTimeout is the timeout of a target, or the default
+func httpTimeout(target *uploaderTarget) time.Duration {
+	if target.Timeout > 0 {
+		return target.Timeout
+	}
+	return defaultHTTPTimeout
+}
+
 type httpUploader struct {
-	baseURL   string
+	// the target without its credentials, for logs
+	name      string
+	baseURL   *url.URL
 	transport *http.Transport
+	client    *http.Client
+	webhook   webhookOptions
+	// the Content-Encoding of the uploads, none when empty
+	compression string
 }
 
 // newHTTPUploader creates a new HTTP uploader
-func newHTTPUploader(url string) uploader {
+func newHTTPUploader(target *uploaderTarget) (uploader, error) {
+	// before the options that tell what to hide are taken out
+	name := redactTarget(target.URL.String())
+	webhook, err := parseWebhookOptions(target)
+	if err != nil {
+		return nil, err
+	}
+
+	base := *target.URL
+	if webhook.format == nil {
+		base.Path = strings.TrimSuffix(base.Path, "/")
+	}
+
+	transport := &http.Transport{}
 	return &httpUploader{
-		baseURL:   url,
-		transport: &http.Transport{},
+		name:        name,
+		baseURL:     &base,
+		transport:   transport,
+		client:      &http.Client{Transport: transport, Timeout: httpTimeout(target)},
+		webhook:     webhook,
+		compression: target.Compression,
+	}, nil
+}
+
+// endpoint is where the uploads of a topic are posted, chat messages go to
+// the webhook URL itself
+func (u *httpUploader) endpoint(topic string) string {
+	if u.webhook.format != nil {
+		return u.baseURL.String()
 	}
+	endpoint := *u.baseURL
+	endpoint.Path += "/" + topic
+	return endpoint.String()
 }
 
-func (u *httpUploader) sendToIngest(body []byte, topic string, state *albionState, identifier string) {
-	// not handling sending identifier since the official usage is with http_pow
+func (u *httpUploader) close() {
+	u.transport.CloseIdleConnections()
+}
 
-	client := &http.Client{Transport: u.transport}
+func (u *httpUploader) sendToIngest(body []byte, topic string, state stateSnapshot, identifier string) error {
+	if u.webhook.format != nil {
+		message, err := u.webhook.format(topic, body, state)
+		if err != nil {
+			return permanentError(fmt.Errorf("error while formatting webhook message: %v", err))
+		}
+		body = message
+	}
 
-	fullURL := u.baseURL + "/" + topic
+	body, err := compressBody(u.compression, body)
+	if err != nil {
+		return permanentError(fmt.Errorf("error while compressing: %v", err))
+	}
 
-	req, err := http.NewRequest("POST", fullURL, bytes.NewBuffer([]byte(body)))
+	req, err := http.NewRequest("POST", u.endpoint(topic), bytes.NewBuffer(body))
 	if err != nil {
-		log.Errorf("Error while create new request: %v", err)
-		return
+		return permanentError(fmt.Errorf("error while create new request: %v", err))
 	}
 
 	req.Header.Set("Content-Type", "application/json")
+	if u.compression != "" {
+		req.Header.Set("Content-Encoding", u.compression)
+	}
+	u.webhook.apply(req.Header, body, topic, identifier)
 
-	resp, err := client.Do(req)
+	resp, err := u.client.Do(req)
 	if err != nil {
-		log.Errorf("Error while sending ingest with data: %v", err)
-		return
+		// the error names the URL, which may hold a webhook token
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return fmt.Errorf("error while sending ingest to %v: %v", u.name, err)
 	}
+	defer resp.Body.Close()
 
-	if resp.StatusCode != 200 {
-		log.Errorf("Got bad response code: %v", resp.StatusCode)
-		return
+	// webhooks answer 204 No Content
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		message, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 512))
+		return httpUploadError(resp, string(message))
 	}
 
 	// See: https://stackoverflow.com/questions/17948827/reusing-http-connections-in-golang
 	io.Copy(ioutil.Discard, resp.Body)
 
-	log.Infof("Successfully sent ingest request to %v", u.baseURL)
-
-	defer resp.Body.Close()
+	log.Infof("Successfully sent ingest request to %v", u.name)
+	return nil
 }
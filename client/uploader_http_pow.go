@@ -2,24 +2,40 @@ package client
 
 import (
 	"bytes"
-	"crypto/rand"
-	"crypto/sha256"
-	"encoding/hex"
+	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"net/url"
-	"runtime"
 	"strconv"
 	"strings"
+	"sync/atomic"
+	"time"
 
 	"github.com/ao-data/albiondata-client/log"
 )
 
+func init() {
+	registerUploader("http+pow", newHTTPUploaderPow)
+	registerUploader("https+pow", newHTTPUploaderPow)
+}
+
 type httpUploaderPow struct {
 	baseURL   string
 	transport *http.Transport
+	client    *http.Client
+	solver    *powSolver
+	// the Content-Encoding of the uploads, none when empty
+	compression string
+
+	prefetched  chan solvedPow
+	prefetching atomic.Bool
+
+	// cancels the solves when the uploader is closed
+	ctx    context.Context
+	cancel context.CancelFunc
 }
 
 type Pow struct {
@@ -27,62 +43,75 @@ type Pow struct {
 	Wanted string `json:"wanted"`
 }
 
-// newHTTPUploaderPow creates a new HTTP uploader
-func newHTTPUploaderPow(url string) uploader {
+// How long a prefetched challenge is used before a fresh one is asked for,
+// the ingest forgets the challenges it handed out after a while
+const powPrefetchMaxAge = time.Minute
 
-	if !ConfigGlobal.NoCPULimit {
-		// Limit to 25% of available cpu cores
-		procs := runtime.NumCPU() / 4
-		if procs < 1 {
-			procs = 1
-		}
-		runtime.GOMAXPROCS(procs)
-	}
+// solvedPow is a challenge with its solution, ready to upload with
+type solvedPow struct {
+	pow      Pow
+	solution string
+	solvedAt time.Time
+}
 
-	url = strings.Replace(url, "https+pow", "https", -1)
-	url = strings.Replace(url, "http+pow", "http", -1)
+// newHTTPUploaderPow creates a new HTTP uploader
+func newHTTPUploaderPow(target *uploaderTarget) (uploader, error) {
+	base := *target.URL
+	base.Scheme = strings.TrimSuffix(base.Scheme, "+pow")
 
+	transport := &http.Transport{}
+	ctx, cancel := context.WithCancel(context.Background())
 	return &httpUploaderPow{
-		baseURL:   url,
-		transport: &http.Transport{},
-	}
+		baseURL:     strings.TrimSuffix(base.String(), "/"),
+		transport:   transport,
+		client:      &http.Client{Transport: transport, Timeout: httpTimeout(target)},
+		solver:      sharedPowSolver(),
+		compression: target.Compression,
+		prefetched:  make(chan solvedPow, 1),
+		ctx:         ctx,
+		cancel:      cancel,
+	}, nil
 }
 
-func (u *httpUploaderPow) getPow(target interface{}) {
+func (u *httpUploaderPow) close() {
+	u.cancel()
+	u.transport.CloseIdleConnections()
+}
+
+func (u *httpUploaderPow) getPow(target interface{}) error {
 	log.Debugf("GETTING POW")
 	fullURL := u.baseURL + "/pow"
 
-	client := &http.Client{}
-	req, _ := http.NewRequest("GET", fullURL, nil)
+	req, err := http.NewRequestWithContext(u.ctx, "GET", fullURL, nil)
+	if err != nil {
+		return permanentError(fmt.Errorf("error while create new request: %v", err))
+	}
 	req.Header.Add("User-Agent", fmt.Sprintf("albiondata-client/%v", version))
-	resp, err := client.Do(req)
+	resp, err := u.client.Do(req)
 
 	if err != nil {
-		log.Errorf("Error in Pow Get request: %v", err)
-		return
+		return fmt.Errorf("error in Pow Get request: %v", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != 200 {
-		log.Errorf("Got bad response code: %v", resp.StatusCode)
-		return
+		body, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 512))
+		return httpUploadError(resp, string(body))
 	}
 
-	json.NewDecoder(resp.Body).Decode(target)
-	if err != nil {
-		log.Errorf("Error in parsing Pow Get request: %v", err)
-		return
+	if err := json.NewDecoder(resp.Body).Decode(target); err != nil {
+		return fmt.Errorf("error in parsing Pow Get request: %v", err)
 	}
+	return nil
 }
 
 // Proves to the server that a pow was solved by submitting
 // the pow's key, the solution and a nats msg as a POST request
 // the topic becomes part of the URL
-func (u *httpUploaderPow) uploadWithPow(pow Pow, solution string, natsmsg []byte, topic string, serverid int, identifier string) {
+func (u *httpUploaderPow) uploadWithPow(pow Pow, solution string, natsmsg []byte, topic string, serverid int, identifier string) error {
 
 	fullURL := u.baseURL + "/pow/" + topic
 
-	client := &http.Client{}
 	data := url.Values{
 		"key":        {pow.Key},
 		"solution":   {solution},
@@ -90,90 +119,90 @@ func (u *httpUploaderPow) uploadWithPow(pow Pow, solution string, natsmsg []byte
 		"natsmsg":    {string(natsmsg)},
 		"identifier": {string(identifier)},
 	}
-	req, _ := http.NewRequest("POST", fullURL, strings.NewReader(data.Encode()))
+	// the whole form is compressed, the key and solution are small next to
+	// the message
+	body, err := compressBody(u.compression, []byte(data.Encode()))
+	if err != nil {
+		return permanentError(fmt.Errorf("error while compressing: %v", err))
+	}
+
+	// closing the uploader cancels the upload like the solving before it
+	req, err := http.NewRequestWithContext(u.ctx, "POST", fullURL, bytes.NewReader(body))
+	if err != nil {
+		return permanentError(fmt.Errorf("error while create new request: %v", err))
+	}
 	req.Header.Add("User-Agent", fmt.Sprintf("albiondata-client/%v", version))
-	resp, err := client.Do(req)
+	if u.compression != "" {
+		req.Header.Set("Content-Encoding", u.compression)
+	}
+	resp, err := u.client.Do(req)
 
 	if err != nil {
-		log.Errorf("Error while proving pow: %v", err)
-		return
+		return fmt.Errorf("error while proving pow: %v", err)
 	}
 
 	defer resp.Body.Close()
 
 	if resp.StatusCode != 200 {
-		body, err := ioutil.ReadAll(resp.Body)
-		if err != nil {
-			log.Fatal(err)
-		}
-		log.Errorf("HTTP Error while proving pow. returned: %v (%v)", resp.StatusCode, string(body))
-		return
+		body, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 512))
+		return httpUploadError(resp, string(body))
 	}
 
-	log.Infof("Successfully sent ingest request to %v", u.baseURL)
+	log.Infof("Successfully sent ingest request to %v", redactTarget(u.baseURL))
+	return nil
 }
 
-// Generates a random hex string e.g.: faa2743d9181dca5
-func randomHex(n int) string {
-    b := make([]byte, n)
-    rand.Read(b)
-    dst := make([]byte, n*2)
-    hex.Encode(dst, b)
-    return string(dst)
+func (u *httpUploaderPow) sendToIngest(body []byte, topic string, state stateSnapshot, identifier string) error {
+	challenge, err := u.takeChallenge()
+	if err != nil {
+		return err
+	}
+	// the next upload finds its challenge solved already
+	u.prefetch()
+	return u.uploadWithPow(challenge.pow, challenge.solution, body, topic, state.AODataServerID, identifier)
 }
 
-// Converts a string to bits e.g.: 0110011...
-func toBinaryBytes(s string) string {
-	var buffer bytes.Buffer
-	for i := 0; i < len(s); i++ {
-		fmt.Fprintf(&buffer, "%08b", s[i])
+// takeChallenge returns the prefetched challenge if it is still fresh, else
+// fetches and solves a new one
+func (u *httpUploaderPow) takeChallenge() (solvedPow, error) {
+	select {
+	case challenge := <-u.prefetched:
+		if time.Since(challenge.solvedAt) < powPrefetchMaxAge {
+			return challenge, nil
+		}
+	default:
 	}
-	return fmt.Sprintf("%s", buffer.Bytes())
+	return u.fetchChallenge()
 }
 
-// Solves a pow looping through possible solutions
-// until a correct one is found
-// returns the solution
-func solvePow(pow Pow) string {
-	wantedLen := len(pow.Wanted)
-	var hexBuf [64]byte
-	var binBuf [512]byte
-
-	prefix := "aod^"
-	sep := "^"
-	for {
-		randhex := randomHex(16)
-		challenge := prefix + randhex + sep + pow.Key
-		hash := sha256.Sum256([]byte(challenge))
-		hex.Encode(hexBuf[:], hash[:])
-
-		idx := 0
-		for i := 0; i < 64; i++ {
-			b := hexBuf[i]
-			for j := 7; j >= 0; j-- {
-				if (b>>j)&1 == 1 {
-					binBuf[idx] = '1'
-				} else {
-					binBuf[idx] = '0'
-				}
-				idx++
-				if idx >= wantedLen {
-					break
-				}
-			}
-			if idx >= wantedLen {
-				break
-			}
+// prefetch fetches and solves a challenge in the background, unless one is
+// on its way or waiting already
+func (u *httpUploaderPow) prefetch() {
+	if len(u.prefetched) > 0 || !u.prefetching.CompareAndSwap(false, true) {
+		return
+	}
+	go func() {
+		defer u.prefetching.Store(false)
+		challenge, err := u.fetchChallenge()
+		if err != nil {
+			log.Debugf("Could not prefetch a pow from %v: %v", u.baseURL, err)
+			return
 		}
-		if string(binBuf[:wantedLen]) == pow.Wanted {
-			return randhex
+		select {
+		case u.prefetched <- challenge:
+		default:
 		}
-	}
+	}()
 }
 
-func (u *httpUploaderPow) sendToIngest(body []byte, topic string, state *albionState, identifier string) {
+func (u *httpUploaderPow) fetchChallenge() (solvedPow, error) {
 	pow := Pow{}
-	u.getPow(&pow)
-	solution := solvePow(pow)
-	u.uploadWithPow(pow, solution, body, topic, state.AODataServerID, identifier)
+	if err := u.getPow(&pow); err != nil {
+		return solvedPow{}, err
+	}
+	solution, err := u.solver.solve(u.ctx, pow)
+	if err != nil {
+		return solvedPow{}, err
+	}
+	return solvedPow{pow: pow, solution: solution, solvedAt: time.Now()}, nil
 }
@@ -0,0 +1,183 @@
+package client
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// The headers of a signed upload. The signature is the HMAC-SHA256 of
+// "<timestamp>.<nonce>.<body>" with the shared secret. Receivers should
+// refuse timestamps more than a few minutes off and nonces seen before, so a
+// captured upload cannot be replayed.
+const (
+	webhookTimestampHeader  = "X-Albiondata-Timestamp"
+	webhookNonceHeader      = "X-Albiondata-Nonce"
+	webhookSignatureHeader  = "X-Albiondata-Signature"
+	webhookIdentifierHeader = "X-Albiondata-Identifier"
+	webhookTopicHeader      = "X-Albiondata-Topic"
+)
+
+// secretOptions are the query options holding credentials, hidden in logs
+var secretOptions = []string{"header", "token", "secret"}
+
+// webhookOptions authenticate and format the uploads of an HTTP target:
+//
+//	https://example.com/ingest?token-env=INGEST_TOKEN&secret-env=INGEST_SECRET&header=X-Team:%20market
+//	https://discord.com/api/webhooks/123/abc?format=discord
+//
+// token and secret may be given directly or, to keep them off the command
+// line, in environment variables named by token-env and secret-env.
+type webhookOptions struct {
+	headers http.Header
+	token   string
+	secret  []byte
+	// posts a chat message to the URL itself instead of the payload to
+	// URL/topic
+	format webhookFormatter
+}
+
+// webhookFormatter turns an upload into a chat message
+type webhookFormatter func(topic string, body []byte, state stateSnapshot) ([]byte, error)
+
+var webhookFormats = map[string]webhookFormatter{
+	"discord": func(topic string, body []byte, state stateSnapshot) ([]byte, error) {
+		return json.Marshal(map[string]string{"username": "Albion Data Client", "content": webhookSummary(topic, body, state, 2000)})
+	},
+	"slack": func(topic string, body []byte, state stateSnapshot) ([]byte, error) {
+		return json.Marshal(map[string]string{"text": webhookSummary(topic, body, state, 3000)})
+	},
+}
+
+// parseWebhookOptions takes the webhook options out of the query of target
+func parseWebhookOptions(target *uploaderTarget) (webhookOptions, error) {
+	var opts webhookOptions
+	query := target.URL.Query()
+
+	for _, header := range query["header"] {
+		name, value, found := strings.Cut(header, ":")
+		if !found || strings.TrimSpace(name) == "" {
+			return opts, fmt.Errorf("invalid header %q, must be Name: value", header)
+		}
+		if opts.headers == nil {
+			opts.headers = make(http.Header)
+		}
+		opts.headers.Add(strings.TrimSpace(name), strings.TrimSpace(value))
+	}
+
+	token, err := optionOrEnv(query, "token")
+	if err != nil {
+		return opts, err
+	}
+	opts.token = token
+
+	secret, err := optionOrEnv(query, "secret")
+	if err != nil {
+		return opts, err
+	}
+	if secret != "" {
+		opts.secret = []byte(secret)
+	}
+
+	if name := query.Get("format"); name != "" {
+		format, ok := webhookFormats[name]
+		if !ok {
+			return opts, fmt.Errorf("unknown format %q, known are discord and slack", name)
+		}
+		opts.format = format
+	}
+
+	for _, option := range []string{"header", "token", "token-env", "secret", "secret-env", "format"} {
+		query.Del(option)
+	}
+	target.URL.RawQuery = query.Encode()
+	return opts, nil
+}
+
+// optionOrEnv reads an option given as name=value or as name-env=VARIABLE
+func optionOrEnv(query url.Values, name string) (string, error) {
+	if value := query.Get(name); value != "" {
+		return value, nil
+	}
+	variable := query.Get(name + "-env")
+	if variable == "" {
+		return "", nil
+	}
+	value, ok := os.LookupEnv(variable)
+	if !ok || value == "" {
+		return "", fmt.Errorf("%v-env names %v, which is not set", name, variable)
+	}
+	return value, nil
+}
+
+// apply sets the headers of an upload of body, which is what is sent
+func (opts webhookOptions) apply(header http.Header, body []byte, topic string, identifier string) {
+	for name, values := range opts.headers {
+		header[name] = values
+	}
+	if opts.token != "" {
+		header.Set("Authorization", "Bearer "+opts.token)
+	}
+	header.Set(webhookTopicHeader, topic)
+	if identifier != "" {
+		header.Set(webhookIdentifierHeader, identifier)
+	}
+
+	if opts.secret != nil {
+		timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+		nonce := randomHex(16)
+		header.Set(webhookTimestampHeader, timestamp)
+		header.Set(webhookNonceHeader, nonce)
+		header.Set(webhookSignatureHeader, "sha256="+webhookSignature(opts.secret, timestamp, nonce, body))
+	}
+}
+
+func webhookSignature(secret []byte, timestamp string, nonce string, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(timestamp + "." + nonce + "."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// webhookSummary describes an upload in a line, like
+// "marketorders.ingest at Martlock Market (west): 12 Orders"
+func webhookSummary(topic string, body []byte, state stateSnapshot, limit int) string {
+	summary := topic
+	if state.Location.ID != "" {
+		summary += " at " + state.Location.Name()
+	}
+	if r, ok := state.realm(); ok {
+		summary += " (" + r.Name + ")"
+	}
+
+	var fields map[string]json.RawMessage
+	if json.Unmarshal(body, &fields) == nil {
+		var counts []string
+		for name, value := range fields {
+			var items []json.RawMessage
+			if json.Unmarshal(value, &items) == nil && len(items) > 0 {
+				counts = append(counts, fmt.Sprintf("%d %v", len(items), name))
+			}
+		}
+		sort.Strings(counts)
+		if len(counts) > 0 {
+			summary += ": " + strings.Join(counts, ", ")
+		}
+	}
+
+	// the chats count characters, and a cut must not split one
+	if utf8.RuneCountInString(summary) > limit {
+		summary = string([]rune(summary)[:limit])
+	}
+	return summary
+}
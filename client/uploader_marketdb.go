@@ -0,0 +1,96 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ao-data/albiondata-client/lib"
+	"github.com/ao-data/albiondata-client/log"
+	"github.com/ao-data/albiondata-client/marketdb"
+)
+
+func init() {
+	registerUploader("marketdb", newMarketDBUploader)
+}
+
+// marketDBUploader keeps the market data in a local marketdb store, the
+// other topics are skipped:
+//
+//	marketdb:///var/lib/albiondata?orders=24h&histories=720h&gold=720h
+type marketDBUploader struct {
+	db *marketdb.DB
+}
+
+func newMarketDBUploader(target *uploaderTarget) (uploader, error) {
+	dir := fileTargetPath(target)
+	if dir == "" {
+		return nil, fmt.Errorf("no directory")
+	}
+
+	var opts marketdb.Options
+	query := target.URL.Query()
+	for name, retention := range map[string]*time.Duration{
+		"orders":    &opts.OrderRetention,
+		"histories": &opts.HistoryRetention,
+		"gold":      &opts.GoldRetention,
+	} {
+		if value := query.Get(name); value != "" {
+			d, err := time.ParseDuration(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %v retention: %v", name, err)
+			}
+			*retention = d
+		}
+	}
+
+	db, err := marketdb.Open(dir, opts)
+	if err != nil {
+		return nil, err
+	}
+	orders, histories, gold := db.Stats()
+	log.Debugf("Opened the market database in %v with %d orders, %d history points and %d gold prices", dir, orders, histories, gold)
+
+	return &marketDBUploader{db: db}, nil
+}
+
+func (u *marketDBUploader) sendToIngest(body []byte, topic string, state stateSnapshot, identifier string) error {
+	realm, _ := state.GetServer()
+	now := time.Now()
+
+	var payload interface{}
+	switch topic {
+	case lib.NatsMarketOrdersIngest:
+		payload = &lib.MarketUpload{}
+	case lib.NatsMarketHistoriesIngest:
+		payload = &lib.MarketHistoriesUpload{}
+	case lib.NatsGoldPricesIngest:
+		payload = &lib.GoldPricesUpload{}
+	default:
+		return nil
+	}
+	if err := json.Unmarshal(body, payload); err != nil {
+		return permanentError(fmt.Errorf("invalid %v payload: %v", topic, err))
+	}
+
+	var err error
+	switch upload := payload.(type) {
+	case *lib.MarketUpload:
+		err = u.db.AddOrders(realm, upload.Orders, now)
+	case *lib.MarketHistoriesUpload:
+		err = u.db.AddHistories(realm, upload, now)
+	case *lib.GoldPricesUpload:
+		err = u.db.AddGoldPrices(realm, upload, now)
+	}
+	if errors.Is(err, marketdb.ErrInvalid) {
+		return permanentError(err)
+	}
+	return err
+}
+
+func (u *marketDBUploader) close() {
+	if err := u.db.Close(); err != nil {
+		log.Errorf("Could not close the market database: %v", err)
+	}
+}
@@ -0,0 +1,132 @@
+package client
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ao-data/albiondata-client/lib"
+	"github.com/ao-data/albiondata-client/mockingest"
+)
+
+func newTestBroker(t *testing.T, username, password string) *mockingest.MQTTBroker {
+	t.Helper()
+
+	broker, err := mockingest.NewMQTTBroker("127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	broker.Username = username
+	broker.Password = password
+	t.Cleanup(func() { broker.Close() })
+	return broker
+}
+
+func newTestMQTTUploader(t *testing.T, target string) *mqttUploader {
+	t.Helper()
+
+	u, _, err := createUploader(target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { u.(*mqttUploader).close() })
+	return u.(*mqttUploader)
+}
+
+func TestMQTTUploaderPublishes(t *testing.T) {
+	broker := newTestBroker(t, "", "")
+	u := newTestMQTTUploader(t, "mqtt://"+broker.Addr()+"?qos=1&retain=true&timeout=5s")
+
+	body := []byte(`{"Prices":[1],"TimeStamps":[2]}`)
+	if err := u.sendToIngest(body, lib.NatsGoldPricesIngest, stateSnapshot{}, "id"); err != nil {
+		t.Fatal(err)
+	}
+
+	// with qos 1 the upload only returns once the broker acknowledged it
+	topic := "albiondata/" + lib.NatsGoldPricesIngest
+	messages := broker.Messages(topic)
+	if len(messages) != 1 {
+		t.Fatalf("broker has %d messages on %v, want 1", len(messages), topic)
+	}
+	if m := messages[0]; m.QoS != 1 || !m.Retain || string(m.Payload) != string(body) {
+		t.Errorf("got qos %d, retain %v, payload %s", m.QoS, m.Retain, m.Payload)
+	}
+	if m, ok := broker.Retained(topic); !ok || string(m.Payload) != string(body) {
+		t.Errorf("the broker did not retain the message")
+	}
+}
+
+func TestMQTTUploaderDefaults(t *testing.T) {
+	broker := newTestBroker(t, "", "")
+	u := newTestMQTTUploader(t, "mqtt://"+broker.Addr()+"?prefix=custom/")
+
+	if err := u.sendToIngest([]byte("{}"), lib.NatsSkillData, stateSnapshot{}, "id"); err != nil {
+		t.Fatal(err)
+	}
+	messages, ok := broker.WaitFor("custom/"+lib.NatsSkillData, 1, 5*time.Second)
+	if !ok {
+		t.Fatalf("no message arrived, the broker has %v", broker.Messages(""))
+	}
+	if m := messages[0]; m.QoS != 0 || m.Retain {
+		t.Errorf("got qos %d and retain %v, want 0 and false", m.QoS, m.Retain)
+	}
+	if _, ok := broker.Retained("custom/" + lib.NatsSkillData); ok {
+		t.Errorf("the broker retained a message sent without retain")
+	}
+}
+
+func TestMQTTUploaderCredentials(t *testing.T) {
+	broker := newTestBroker(t, "albion", "s3cret")
+
+	u := newTestMQTTUploader(t, "mqtt://albion:s3cret@"+broker.Addr()+"?qos=1")
+	if err := u.sendToIngest([]byte("{}"), lib.NatsSkillData, stateSnapshot{}, "id"); err != nil {
+		t.Fatalf("the right credentials were refused: %v", err)
+	}
+
+	for _, target := range []string{
+		"mqtt://albion:wrong@" + broker.Addr() + "?qos=1",
+		"mqtt://" + broker.Addr() + "?qos=1",
+	} {
+		u := newTestMQTTUploader(t, target)
+		err := u.sendToIngest([]byte("{}"), lib.NatsSkillData, stateSnapshot{}, "id")
+		if err == nil || !strings.Contains(err.Error(), "bad user name or password") {
+			t.Errorf("%v: got %v, want the connection refused", redactTarget(target), err)
+		}
+	}
+
+	if got := len(broker.Messages("")); got != 1 {
+		t.Errorf("broker has %d messages, want only the one sent with the right credentials", got)
+	}
+	if got := broker.Connects(); got != 1 {
+		t.Errorf("broker accepted %d connections, want 1", got)
+	}
+}
+
+func TestMQTTUploaderReconnects(t *testing.T) {
+	broker := newTestBroker(t, "", "")
+	u := newTestMQTTUploader(t, "mqtt://"+broker.Addr()+"?qos=1&timeout=5s")
+
+	if err := u.sendToIngest([]byte("{}"), lib.NatsSkillData, stateSnapshot{}, "first"); err != nil {
+		t.Fatal(err)
+	}
+
+	u.mu.Lock()
+	conn := u.conn
+	u.mu.Unlock()
+	broker.DropConnections()
+	select {
+	case <-conn.closed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the uploader did not notice the dropped connection")
+	}
+
+	if err := u.sendToIngest([]byte("{}"), lib.NatsSkillData, stateSnapshot{}, "second"); err != nil {
+		t.Fatalf("upload after the connection was dropped: %v", err)
+	}
+	if got := len(broker.Messages("albiondata/" + lib.NatsSkillData)); got != 2 {
+		t.Errorf("broker has %d messages, want 2", got)
+	}
+	if got := broker.Connects(); got != 2 {
+		t.Errorf("broker accepted %d connections, want 2", got)
+	}
+}
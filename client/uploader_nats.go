@@ -1,30 +1,73 @@
 package client
 
 import (
-	"github.com/ao-data/albiondata-client/log"
+	"fmt"
+	"sync"
+
 	nats "github.com/nats-io/go-nats"
 )
 
+func init() {
+	registerUploader("nats", newNATSUploader)
+}
+
 type natsUploader struct {
 	isPrivate bool
 	url       string
-	nc        *nats.Conn
+	options   []nats.Option
+
+	mu sync.Mutex
+	nc *nats.Conn
 }
 
 // newNATSUploader creates a new NATS uploader
-func newNATSUploader(url string) uploader {
-	nc, _ := nats.Connect(url)
-
-	return &natsUploader{
-		url: url,
-		nc:  nc,
+func newNATSUploader(target *uploaderTarget) (uploader, error) {
+	u := &natsUploader{url: target.URL.String()}
+	if target.Timeout > 0 {
+		u.options = append(u.options, nats.Timeout(target.Timeout))
 	}
+
+	// a failed connect is retried on the first upload
+	u.nc, _ = nats.Connect(u.url, u.options...)
+
+	return u, nil
 }
 
-func (u *natsUploader) sendToIngest(body []byte, topic string, state *albionState, identifier string) {
+func (u *natsUploader) sendToIngest(body []byte, topic string, state stateSnapshot, identifier string) error {
 	// not handling sending identifier since the official usage is with http_pow
 
-	if err := u.nc.Publish(topic, body); err != nil {
-		log.Errorf("Error while sending ingest to nats with data: %v", err)
+	nc, err := u.conn()
+	if err != nil {
+		return fmt.Errorf("error while connecting to nats: %v", err)
+	}
+
+	if err := nc.Publish(topic, body); err != nil {
+		return fmt.Errorf("error while sending ingest to nats with data: %v", err)
+	}
+	return nil
+}
+
+func (u *natsUploader) close() {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if u.nc != nil {
+		u.nc.Close()
+		u.nc = nil
+	}
+}
+
+// conn returns the connection, connecting again if the first attempt failed
+func (u *natsUploader) conn() (*nats.Conn, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if u.nc == nil {
+		nc, err := nats.Connect(u.url, u.options...)
+		if err != nil {
+			return nil, err
+		}
+		u.nc = nc
 	}
+	return u.nc, nil
 }
@@ -0,0 +1,33 @@
+package client
+
+import (
+	"github.com/ao-data/albiondata-client/log"
+)
+
+func init() {
+	registerUploader("noop", newNoopUploader)
+	registerUploader("log", newLogUploader)
+}
+
+// noopUploader accepts every payload and drops it
+type noopUploader struct{}
+
+func newNoopUploader(target *uploaderTarget) (uploader, error) {
+	return noopUploader{}, nil
+}
+
+func (u noopUploader) sendToIngest(body []byte, topic string, state stateSnapshot, identifier string) error {
+	return nil
+}
+
+// logUploader writes every payload to the log instead of sending it
+type logUploader struct{}
+
+func newLogUploader(target *uploaderTarget) (uploader, error) {
+	return logUploader{}, nil
+}
+
+func (u logUploader) sendToIngest(body []byte, topic string, state stateSnapshot, identifier string) error {
+	log.Infof("Upload %v to %v from %v (%d bytes): %s", identifier, topic, state.Location, len(body), body)
+	return nil
+}
@@ -0,0 +1,169 @@
+package client
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ao-data/albiondata-client/log"
+)
+
+// https+pow://albion-online-data.com is used as a magic placeholder for every
+// realm there is
+const realmIngestPlaceholder = "https+pow://albion-online-data.com"
+
+// uploaderPool holds the upload queue, and with it the uploader, of every
+// configured target. They are built once and live until shutdown. Targets
+// with the realm placeholder get a queue per realm, built the first time the
+// realm is seen.
+type uploaderPool struct {
+	dir    string
+	maxAge time.Duration
+
+	public  []string
+	private []*uploadQueue
+
+	mu      sync.Mutex
+	queues  map[string]*uploadQueue
+	byRealm map[string][]*uploadQueue
+}
+
+func splitTargets(targets string) []string {
+	var result []string
+	for _, target := range strings.Split(targets, ",") {
+		if target = strings.TrimSpace(target); target != "" {
+			result = append(result, target)
+		}
+	}
+	return result
+}
+
+// newUploaderPool builds the uploaders for the comma separated public and
+// private targets
+func newUploaderPool(public string, private string, dir string, maxAge time.Duration) *uploaderPool {
+	p := &uploaderPool{
+		dir:     dir,
+		maxAge:  maxAge,
+		public:  splitTargets(public),
+		queues:  make(map[string]*uploadQueue),
+		byRealm: make(map[string][]*uploadQueue),
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, target := range splitTargets(private) {
+		if q := p.queue(target); q != nil {
+			p.private = append(p.private, q)
+		}
+	}
+	for _, r := range realms.realms {
+		p.realmQueues(r.IngestURL)
+	}
+
+	return p
+}
+
+// queue returns the queue of a target, building it if needed. It returns nil
+// for invalid targets. Must be called with the mutex held.
+func (p *uploaderPool) queue(target string) *uploadQueue {
+	if q, ok := p.queues[target]; ok {
+		return q
+	}
+
+	var q *uploadQueue
+	u, options, err := createUploader(target)
+	if err != nil {
+		log.Error(err)
+	} else {
+		q = newUploadQueue(target, u, queueDir(p.dir, target), p.maxAge)
+		q.topics = options.Topics
+	}
+	// invalid targets are remembered too, so they are reported once
+	p.queues[target] = q
+	return q
+}
+
+// realmQueues returns the public queues for the ingest of a realm. Must be
+// called with the mutex held.
+func (p *uploaderPool) realmQueues(ingest string) []*uploadQueue {
+	if queues, ok := p.byRealm[ingest]; ok {
+		return queues
+	}
+
+	var queues []*uploadQueue
+	for _, target := range p.public {
+		if base, options, _ := strings.Cut(target, "?"); base == realmIngestPlaceholder {
+			// the realm is not known yet
+			if ingest == "" {
+				continue
+			}
+			target = ingest
+			if options != "" {
+				target += "?" + options
+			}
+		}
+		if q := p.queue(target); q != nil {
+			queues = append(queues, q)
+		}
+	}
+
+	p.byRealm[ingest] = queues
+	return queues
+}
+
+// publicQueues returns the queues public data of the player's realm goes to
+func (p *uploaderPool) publicQueues(state stateSnapshot) []*uploadQueue {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.realmQueues(state.AODataIngestBaseURL)
+}
+
+// privateQueues returns the queues private data goes to
+func (p *uploaderPool) privateQueues() []*uploadQueue {
+	return p.private
+}
+
+func (p *uploaderPool) logStats() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, q := range p.queues {
+		if q != nil {
+			q.logStats()
+		}
+	}
+}
+
+// close stops every queue and closes the uploaders, after waiting up to
+// timeout for the queued uploads to go out. It only waits for what was queued
+// when it is called, whatever fills the queues must be stopped before.
+func (p *uploaderPool) close(timeout time.Duration) {
+	// the mutex is not held while waiting, lookups and stats go on meanwhile
+	deadline := time.Now().Add(timeout)
+	for _, q := range p.openQueues() {
+		for !q.idle() && time.Now().Before(deadline) {
+			time.Sleep(100 * time.Millisecond)
+		}
+	}
+
+	// again, a late lookup may have built one more
+	for _, q := range p.openQueues() {
+		q.close()
+		q.logStats()
+	}
+}
+
+// openQueues returns the queues of the valid targets
+func (p *uploaderPool) openQueues() []*uploadQueue {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	queues := make([]*uploadQueue, 0, len(p.queues))
+	for _, q := range p.queues {
+		if q != nil {
+			queues = append(queues, q)
+		}
+	}
+	return queues
+}
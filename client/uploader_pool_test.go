@@ -0,0 +1,134 @@
+package client
+
+import (
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ao-data/albiondata-client/lib"
+)
+
+// poolUploader holds its uploads until release is closed
+type poolUploader struct {
+	release chan struct{}
+	calls   atomic.Int64
+}
+
+func (u *poolUploader) sendToIngest(body []byte, topic string, state stateSnapshot, identifier string) error {
+	u.calls.Add(1)
+	<-u.release
+	return nil
+}
+
+// poolUploaders are the uploaders built for the test-pool targets, by target
+var poolUploaders sync.Map
+
+func init() {
+	registerUploader("test-pool", func(target *uploaderTarget) (uploader, error) {
+		u := &poolUploader{release: make(chan struct{})}
+		poolUploaders.Store(target.URL.String(), u)
+		return u, nil
+	})
+}
+
+func TestUploaderPoolCloseLeavesTheLock(t *testing.T) {
+	p := newUploaderPool("test-pool://slow", "", "", time.Hour)
+	value, ok := poolUploaders.Load("test-pool://slow")
+	if !ok {
+		t.Fatal("the pool built no uploader")
+	}
+	u := value.(*poolUploader)
+
+	p.publicQueues(stateSnapshot{})[0].push([]byte("{}"), "topic", stateSnapshot{}, "id")
+	waitForStat(t, &u.calls, 1)
+
+	closed := make(chan struct{})
+	go func() {
+		p.close(5 * time.Second)
+		close(closed)
+	}()
+
+	// the upload is still going, close waits for it
+	looked := make(chan struct{})
+	go func() {
+		p.publicQueues(stateSnapshot{})
+		p.logStats()
+		close(looked)
+	}()
+	select {
+	case <-looked:
+	case <-time.After(time.Second):
+		t.Fatal("the pool was locked while close waited")
+	}
+	select {
+	case <-closed:
+		t.Fatal("close did not wait for the upload")
+	default:
+	}
+
+	close(u.release)
+	select {
+	case <-closed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("close did not return once the upload was done")
+	}
+}
+
+// queueTargets lists the targets of queues
+func queueTargets(queues []*uploadQueue) []string {
+	targets := []string{}
+	for _, q := range queues {
+		targets = append(targets, q.target)
+	}
+	return targets
+}
+
+func TestUploaderPoolExpandsThePlaceholder(t *testing.T) {
+	saved := realms
+	defer func() { realms = saved }()
+	realms = mustRealmRegistry([]realm{
+		{ID: 1, Name: "west", IngestURL: "test-pool://west", Ranges: []string{"5.188.125.0/24"}},
+		{ID: 2, Name: "east", IngestURL: "test-pool://east", Ranges: []string{"5.45.187.0/24"}},
+	})
+
+	public := realmIngestPlaceholder + "?topics=" + lib.NatsGoldPricesIngest + ", test-pool://everywhere"
+	p := newUploaderPool(public, "test-pool://private", "", time.Hour)
+	defer p.close(time.Second)
+
+	tests := []struct {
+		ingest string
+		want   []string
+	}{
+		{"test-pool://west", []string{"test-pool://west?topics=" + lib.NatsGoldPricesIngest, "test-pool://everywhere"}},
+		{"test-pool://east", []string{"test-pool://east?topics=" + lib.NatsGoldPricesIngest, "test-pool://everywhere"}},
+		// a realm from the server list, not configured
+		{"test-pool://north", []string{"test-pool://north?topics=" + lib.NatsGoldPricesIngest, "test-pool://everywhere"}},
+		// the realm is not known yet
+		{"", []string{"test-pool://everywhere"}},
+	}
+	for _, tt := range tests {
+		queues := p.publicQueues(stateSnapshot{AODataIngestBaseURL: tt.ingest})
+		if got := queueTargets(queues); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%q: got the targets %v, want %v", tt.ingest, got, tt.want)
+			continue
+		}
+		if topics := queues[0].topics; tt.ingest != "" && !reflect.DeepEqual(topics, map[string]bool{lib.NatsGoldPricesIngest: true}) {
+			t.Errorf("%q: the expanded target kept the topics %v", tt.ingest, topics)
+		}
+	}
+
+	// every realm shares the queue of a plain target, and gets it again
+	west := p.publicQueues(stateSnapshot{AODataIngestBaseURL: "test-pool://west"})
+	east := p.publicQueues(stateSnapshot{AODataIngestBaseURL: "test-pool://east"})
+	if west[1] != east[1] {
+		t.Error("the realms have queues of their own for the same target")
+	}
+	if again := p.publicQueues(stateSnapshot{AODataIngestBaseURL: "test-pool://west"}); again[0] != west[0] {
+		t.Error("the queue of a realm was built twice")
+	}
+	if got := queueTargets(p.privateQueues()); !reflect.DeepEqual(got, []string{"test-pool://private"}) {
+		t.Errorf("got the private targets %v", got)
+	}
+}
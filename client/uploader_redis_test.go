@@ -0,0 +1,241 @@
+package client
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/ao-data/albiondata-client/lib"
+	"github.com/ao-data/albiondata-client/mockingest"
+)
+
+func newTestRedis(t *testing.T, username, password string) *mockingest.RedisServer {
+	t.Helper()
+
+	server, err := mockingest.NewRedisServer("127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if password != "" {
+		server.RequireAuth(username, password)
+	}
+	t.Cleanup(func() { server.Close() })
+	return server
+}
+
+func newTestRedisUploader(t *testing.T, target string) *redisUploader {
+	t.Helper()
+
+	u, _, err := createUploader(target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { u.(*redisUploader).close() })
+	return u.(*redisUploader)
+}
+
+func permanent(err error) bool {
+	var failure *uploadError
+	return errors.As(err, &failure) && failure.permanent
+}
+
+func TestRedisReply(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want interface{}
+		err  string
+	}{
+		{"+OK\r\n", "OK", ""},
+		{"-ERR unknown command\r\n", nil, "ERR unknown command"},
+		{":42\r\n", int64(42), ""},
+		{"$5\r\nhello\r\n", "hello", ""},
+		{"$0\r\n\r\n", "", ""},
+		{"$-1\r\n", nil, ""},
+		{"*-1\r\n", nil, ""},
+		{"*3\r\n$1\r\na\r\n:2\r\n-WRONGTYPE no\r\n", []interface{}{"a", int64(2), redisError("WRONGTYPE no")}, ""},
+		{"*2\r\n*1\r\n+x\r\n$-1\r\n", []interface{}{[]interface{}{"x"}, nil}, ""},
+		{"\r\n", nil, "empty reply"},
+		{"?what\r\n", nil, "unexpected reply"},
+		{"$5\r\nhel", nil, "EOF"},
+	}
+	for _, tt := range tests {
+		conn := &redisConn{r: bufio.NewReader(strings.NewReader(tt.raw))}
+		got, err := conn.reply()
+		if tt.err != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("%q: got %v, want the error %q", tt.raw, err, tt.err)
+			}
+			continue
+		}
+		if err != nil || !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%q: got %#v (%v), want %#v", tt.raw, got, err, tt.want)
+		}
+	}
+}
+
+func TestRedisUploaderAdds(t *testing.T) {
+	server := newTestRedis(t, "", "")
+	u := newTestRedisUploader(t, "redis://"+server.Addr()+"?maxlen=2")
+
+	state := stateSnapshot{GameServerIP: "5.188.125.10"}
+	for i := 0; i < 3; i++ {
+		body := fmt.Sprintf(`{"Prices":[%d],"Timestamps":[2]}`, i)
+		if err := u.sendToIngest([]byte(body), lib.NatsGoldPricesIngest, state, fmt.Sprint("id-", i)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	entries := server.Entries(defaultRedisPrefix + lib.NatsGoldPricesIngest)
+	if len(entries) != 2 {
+		t.Fatalf("the stream has %d entries, want the 2 of maxlen", len(entries))
+	}
+	want := map[string]string{
+		"payload":    `{"Prices":[2],"Timestamps":[2]}`,
+		"identifier": "id-2",
+		"realm":      "west",
+		"serverid":   "1",
+	}
+	if got := entries[1].Fields; !reflect.DeepEqual(got, want) {
+		t.Errorf("got the fields %v, want %v", got, want)
+	}
+	if entries[1].DB != 0 {
+		t.Errorf("added to database %d, want 0", entries[1].DB)
+	}
+}
+
+func TestRedisUploaderAuthAndSelect(t *testing.T) {
+	server := newTestRedis(t, "albion", "s3cret")
+
+	u := newTestRedisUploader(t, "redis://albion:s3cret@"+server.Addr()+"/3")
+	if err := u.sendToIngest([]byte("{}"), lib.NatsSkillData, stateSnapshot{}, "id"); err != nil {
+		t.Fatalf("the right credentials were refused: %v", err)
+	}
+	if entries := server.Entries(""); len(entries) != 1 || entries[0].DB != 3 {
+		t.Errorf("got the entries %+v, want one in database 3", entries)
+	}
+
+	for _, target := range []string{
+		"redis://albion:wrong@" + server.Addr(),
+		// logs in as default
+		"redis://:s3cret@" + server.Addr(),
+		// never authenticates
+		"redis://" + server.Addr(),
+		"redis://albion:s3cret@" + server.Addr() + "/20",
+	} {
+		u := newTestRedisUploader(t, target)
+		err := u.sendToIngest([]byte("{}"), lib.NatsSkillData, stateSnapshot{}, "id")
+		if err == nil || !permanent(err) {
+			t.Errorf("%v: got %v, want a permanent error", redactTarget(target), err)
+		}
+		// a connection refused while connecting gives its slot back
+		if len(u.slots) != len(u.idle) {
+			t.Errorf("%v: %d connections are open, %d idle", redactTarget(target), len(u.slots), len(u.idle))
+		}
+	}
+
+	if got := len(server.Entries("")); got != 1 {
+		t.Errorf("the server has %d entries, want only the one added with the right credentials", got)
+	}
+}
+
+func TestRedisUploaderPool(t *testing.T) {
+	server := newTestRedis(t, "", "")
+	u := newTestRedisUploader(t, "redis://"+server.Addr()+"?pool=2")
+
+	const uploads = 20
+	var wg sync.WaitGroup
+	for i := 0; i < uploads; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := u.sendToIngest([]byte("{}"), lib.NatsSkillData, stateSnapshot{}, "id"); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := len(server.Entries("")); got != uploads {
+		t.Errorf("the server has %d entries, want %d", got, uploads)
+	}
+	if got := server.Connects(); got > 2 {
+		t.Errorf("opened %d connections, want at most the 2 of the pool", got)
+	}
+	if len(u.idle) != len(u.slots) {
+		t.Errorf("%d connections are idle, %d are open", len(u.idle), len(u.slots))
+	}
+}
+
+func TestRedisUploaderKeepsTheConnectionAfterAnErrorReply(t *testing.T) {
+	server := newTestRedis(t, "", "")
+	u := newTestRedisUploader(t, "redis://"+server.Addr()+"?pool=1")
+
+	server.FailWith("WRONGTYPE Operation against a key holding the wrong kind of value")
+	err := u.sendToIngest([]byte("{}"), lib.NatsSkillData, stateSnapshot{}, "id")
+	if !permanent(err) {
+		t.Errorf("got %v, want a permanent error", err)
+	}
+	server.FailWith("LOADING Redis is loading the dataset in memory")
+	err = u.sendToIngest([]byte("{}"), lib.NatsSkillData, stateSnapshot{}, "id")
+	if err == nil || permanent(err) {
+		t.Errorf("got %v, want an error to retry", err)
+	}
+
+	server.FailWith("")
+	if err := u.sendToIngest([]byte("{}"), lib.NatsSkillData, stateSnapshot{}, "id"); err != nil {
+		t.Fatal(err)
+	}
+	if got := server.Connects(); got != 1 {
+		t.Errorf("opened %d connections, want the 1 kept through the error replies", got)
+	}
+}
+
+func TestRedisUploaderReconnects(t *testing.T) {
+	server := newTestRedis(t, "", "")
+	u := newTestRedisUploader(t, "redis://"+server.Addr()+"?pool=1")
+
+	if err := u.sendToIngest([]byte("{}"), lib.NatsSkillData, stateSnapshot{}, "first"); err != nil {
+		t.Fatal(err)
+	}
+	server.DropConnections()
+
+	// the idle connection was dropped, the upload fails and frees its slot
+	err := u.sendToIngest([]byte("{}"), lib.NatsSkillData, stateSnapshot{}, "lost")
+	if err == nil || permanent(err) {
+		t.Fatalf("got %v, want an error to retry", err)
+	}
+	if len(u.slots) != 0 {
+		t.Fatalf("the dropped connection still holds its slot")
+	}
+
+	if err := u.sendToIngest([]byte("{}"), lib.NatsSkillData, stateSnapshot{}, "second"); err != nil {
+		t.Fatalf("upload after the connection was dropped: %v", err)
+	}
+	if got := server.Connects(); got != 2 {
+		t.Errorf("opened %d connections, want 2", got)
+	}
+}
+
+func TestRedisUploadError(t *testing.T) {
+	tests := []struct {
+		err       error
+		permanent bool
+	}{
+		{fmt.Errorf("adding: %w", redisError("WRONGTYPE Operation against a key holding the wrong kind of value")), true},
+		{fmt.Errorf("connecting: %w", redisError("WRONGPASS invalid username-password pair")), true},
+		{fmt.Errorf("adding: %w", redisError("LOADING Redis is loading the dataset in memory")), false},
+		{fmt.Errorf("adding: %w", redisError("READONLY You can't write against a read only replica.")), false},
+		{errors.New("connection refused"), false},
+	}
+	for _, tt := range tests {
+		var failure *uploadError
+		permanent := errors.As(redisUploadError(tt.err), &failure) && failure.permanent
+		if permanent != tt.permanent {
+			t.Errorf("%v: permanent = %v, want %v", tt.err, permanent, tt.permanent)
+		}
+	}
+}
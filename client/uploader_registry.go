@@ -0,0 +1,152 @@
+package client
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// uploaderTarget is a parsed ingest target. The options every uploader
+// understands are taken out of the query, the rest is left for the factory.
+//
+//	https://example.com/ingest?timeout=10s&topics=marketorders.ingest,goldprices.ingest
+type uploaderTarget struct {
+	URL *url.URL
+
+	// How long one upload may take, 0 for the uploader's default
+	Timeout time.Duration
+	// The topics sent to the target, all when empty
+	Topics map[string]bool
+	// How the payloads are compressed, empty for none. Only the HTTP
+	// uploaders compress, see compressBody.
+	Compression string
+}
+
+// uploaderFactory builds the uploader of a target
+type uploaderFactory func(target *uploaderTarget) (uploader, error)
+
+// uploaderFactories are the uploaders by scheme, registered by each uploader
+// in its init()
+var uploaderFactories = make(map[string]uploaderFactory)
+
+func registerUploader(scheme string, factory uploaderFactory) {
+	if _, exists := uploaderFactories[scheme]; exists {
+		panic(fmt.Sprintf("uploader scheme %q registered twice", scheme))
+	}
+	uploaderFactories[scheme] = factory
+}
+
+// UploaderTarget is a parsed ingest target, as handed to the factory of a
+// registered scheme. The options every uploader understands are taken out of
+// the query of URL already.
+type UploaderTarget struct {
+	URL *url.URL
+	// How long one upload may take, 0 for the uploader's default
+	Timeout time.Duration
+}
+
+// UploaderFactory builds the Uploader of a target
+type UploaderFactory func(target UploaderTarget) (Uploader, error)
+
+// RegisterUploader adds a scheme for -i and -p, so other code can send the
+// payloads elsewhere without changing this package. It must be called
+// before the client starts and panics if the scheme is taken.
+//
+//	client.RegisterUploader("kafka", newKafkaUploader)
+func RegisterUploader(scheme string, factory UploaderFactory) {
+	registerUploader(scheme, func(target *uploaderTarget) (uploader, error) {
+		u, err := factory(UploaderTarget{URL: target.URL, Timeout: target.Timeout})
+		if err != nil {
+			return nil, err
+		}
+		return externalUploader{u}, nil
+	})
+}
+
+// uploaderSchemes lists the registered schemes, for help texts and errors
+func uploaderSchemes() []string {
+	schemes := make([]string, 0, len(uploaderFactories))
+	for scheme := range uploaderFactories {
+		schemes = append(schemes, scheme)
+	}
+	sort.Strings(schemes)
+	return schemes
+}
+
+// compressions are the values of the compression option
+var compressions = map[string]bool{
+	"":     true,
+	"none": true,
+	"gzip": true,
+	"zstd": true,
+}
+
+// parseTarget reads a target. A bare word like noop is a scheme without an
+// address.
+func parseTarget(raw string) (*uploaderTarget, error) {
+	if base, options, found := strings.Cut(raw, "?"); !strings.Contains(base, ":") {
+		raw = base + ":"
+		if found {
+			raw += "?" + options
+		}
+	}
+
+	parsed, err := url.Parse(raw)
+	if err != nil {
+		return nil, err
+	}
+	if parsed.Scheme == "" {
+		return nil, fmt.Errorf("no scheme")
+	}
+
+	target := &uploaderTarget{URL: parsed}
+
+	query := parsed.Query()
+	if value := query.Get("timeout"); value != "" {
+		if target.Timeout, err = time.ParseDuration(value); err != nil {
+			return nil, fmt.Errorf("invalid timeout: %v", err)
+		}
+	}
+	if value := query.Get("topics"); value != "" {
+		target.Topics = make(map[string]bool)
+		for _, topic := range strings.Split(value, ",") {
+			target.Topics[strings.TrimSpace(topic)] = true
+		}
+	}
+	if value := query.Get("compression"); value != "" {
+		if !compressions[value] {
+			return nil, fmt.Errorf("unknown compression %q", value)
+		}
+		if value != "none" {
+			target.Compression = value
+		}
+	}
+
+	query.Del("timeout")
+	query.Del("topics")
+	query.Del("compression")
+	parsed.RawQuery = query.Encode()
+
+	return target, nil
+}
+
+// createUploader builds the uploader of a target by its scheme
+func createUploader(raw string) (uploader, *uploaderTarget, error) {
+	target, err := parseTarget(raw)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid ingest target %v: %v", redactTarget(raw), err)
+	}
+
+	factory, ok := uploaderFactories[target.URL.Scheme]
+	if !ok {
+		return nil, nil, fmt.Errorf("invalid ingest target %v: unknown scheme %q, known are %v", redactTarget(raw), target.URL.Scheme, strings.Join(uploaderSchemes(), ", "))
+	}
+
+	u, err := factory(target)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid ingest target %v: %v", redactTarget(raw), err)
+	}
+	return u, target, nil
+}
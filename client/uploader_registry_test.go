@@ -0,0 +1,103 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ao-data/albiondata-client/lib"
+)
+
+// recordingUploader is registered like code outside the package would
+type recordingUploader struct {
+	target UploaderTarget
+	err    error
+
+	mu     sync.Mutex
+	states []UploadState
+	closed bool
+}
+
+func (u *recordingUploader) SendToIngest(body []byte, topic string, state UploadState, identifier string) error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.states = append(u.states, state)
+	return u.err
+}
+
+func (u *recordingUploader) Close() error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.closed = true
+	return nil
+}
+
+var recorded = make(chan *recordingUploader, 10)
+
+func init() {
+	RegisterUploader("test-recording", func(target UploaderTarget) (Uploader, error) {
+		if target.URL.Query().Get("fail") != "" {
+			return nil, errors.New("asked to fail")
+		}
+		u := &recordingUploader{target: target}
+		if target.URL.Query().Get("refuse") != "" {
+			u.err = PermanentError(errors.New("refused"))
+		}
+		recorded <- u
+		return u, nil
+	})
+}
+
+func TestRegisteredUploader(t *testing.T) {
+	u, target, err := createUploader("test-recording://somewhere?timeout=3s&topics=goldprices.ingest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	external := <-recorded
+
+	if external.target.Timeout != 3*time.Second || external.target.URL.Host != "somewhere" {
+		t.Errorf("the factory got %+v", external.target)
+	}
+	if external.target.URL.RawQuery != "" {
+		t.Errorf("the common options were left in the query: %q", external.target.URL.RawQuery)
+	}
+	if !target.Topics[lib.NatsGoldPricesIngest] {
+		t.Errorf("topics = %v", target.Topics)
+	}
+
+	state := stateSnapshot{CharacterName: "Tester", AODataServerID: 2, Location: lib.Location{ID: "3005"}}
+	if err := u.sendToIngest([]byte("{}"), lib.NatsGoldPricesIngest, state, "id"); err != nil {
+		t.Fatal(err)
+	}
+	if len(external.states) != 1 {
+		t.Fatalf("sent %d uploads, want 1", len(external.states))
+	}
+	if got := external.states[0]; got.CharacterName != "Tester" || got.ServerID != 2 || got.Location.ID != "3005" {
+		t.Errorf("the uploader got state %+v", got)
+	}
+
+	u.(closingUploader).close()
+	if !external.closed {
+		t.Error("the uploader was not closed")
+	}
+}
+
+func TestRegisteredUploaderErrors(t *testing.T) {
+	if _, _, err := createUploader("test-recording://somewhere?fail=1"); err == nil {
+		t.Error("the error of the factory was lost")
+	}
+
+	u, _, err := createUploader("test-recording://somewhere?refuse=1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	<-recorded
+
+	var failure *uploadError
+	err = u.sendToIngest([]byte("{}"), "topic", stateSnapshot{}, "id")
+	if !errors.As(fmt.Errorf("wrapped: %w", err), &failure) || !failure.permanent {
+		t.Errorf("PermanentError was not permanent: %v", err)
+	}
+}
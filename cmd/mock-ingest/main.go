@@ -0,0 +1,75 @@
+// mock-ingest runs a local stand-in for the Albion Data ingest, to try the
+// client without sending anything to the real one:
+//
+//	go run ./cmd/mock-ingest -difficulty 16 -store received.jsonl
+//	go run albiondata-client.go -o capture.pcap -i http+pow://127.0.0.1:3000
+//
+// What it received is at http://127.0.0.1:3000/received. With -mqtt it also
+// runs an MQTT broker stand-in for the mqtt:// uploader.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"net/http"
+	"os"
+	"sync"
+
+	"github.com/ao-data/albiondata-client/log"
+	"github.com/ao-data/albiondata-client/mockingest"
+)
+
+func main() {
+	addr := flag.String("addr", "127.0.0.1:3000", "Address to listen on.")
+	difficulty := flag.Int("difficulty", 16, "How many bits of proof of work the uploads need, 0 to accept any solution.")
+	store := flag.String("store", "", "Append the received payloads to this file, one JSON per line.")
+	debug := flag.Bool("debug", false, "Log the bodies of the received payloads.")
+	secret := flag.String("secret", "", "Require plain uploads to be signed with this HMAC secret.")
+	token := flag.String("token", "", "Require plain uploads to carry this bearer token.")
+	mqttAddr := flag.String("mqtt", "", "Also run an MQTT broker stand-in on this address, e.g. 127.0.0.1:1883.")
+	flag.Parse()
+
+	server := mockingest.NewServer(*difficulty)
+	server.Secret = *secret
+	server.Token = *token
+
+	if *store != "" {
+		file, err := os.OpenFile(*store, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+		if err != nil {
+			log.Fatalf("Could not open %v: %v", *store, err)
+		}
+		defer file.Close()
+
+		var mu sync.Mutex
+		encoder := json.NewEncoder(file)
+		server.OnPayload = func(p mockingest.Payload) {
+			mu.Lock()
+			defer mu.Unlock()
+			if err := encoder.Encode(p); err != nil {
+				log.Errorf("Could not store a %v payload: %v", p.Topic, err)
+			}
+		}
+	}
+
+	if *debug {
+		store := server.OnPayload
+		server.OnPayload = func(p mockingest.Payload) {
+			log.Infof("%v: %s", p.Topic, p.Body)
+			if store != nil {
+				store(p)
+			}
+		}
+	}
+
+	if *mqttAddr != "" {
+		broker, err := mockingest.NewMQTTBroker(*mqttAddr)
+		if err != nil {
+			log.Fatalf("Could not start the MQTT broker: %v", err)
+		}
+		defer broker.Close()
+		log.Infof("MQTT broker stand-in listening on %v", broker.Addr())
+	}
+
+	log.Infof("Mock ingest listening on http://%v with a difficulty of %d", *addr, *difficulty)
+	log.Fatal(http.ListenAndServe(*addr, server))
+}
@@ -1,5 +1,7 @@
 package lib
 
+import "time"
+
 type PrivateUpload struct {
 	CharacterId   CharacterID `json:"CharacterId"`
 	CharacterName string      `json:"CharacterName"`
@@ -16,3 +18,15 @@ type PersonalizedUpload interface {
 
 // Represents a character identifier in its UUID-style string format
 type CharacterID string
+
+// ticksEpoch is 1970-01-01 in .NET ticks
+const ticksEpoch = 621355968000000000
+
+// GameTime reads a timestamp of the game. The game sends .NET ticks, 100ns
+// since year 1, small values are taken as unix seconds.
+func GameTime(timestamp int64) time.Time {
+	if timestamp > 1e15 {
+		return time.Unix(0, (timestamp-ticksEpoch)*100).UTC()
+	}
+	return time.Unix(timestamp, 0).UTC()
+}
@@ -0,0 +1,193 @@
+package lib
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// The world data shipped with the client, it names the known market locations
+//
+//go:embed data/world.json
+var worldData []byte
+
+// LocationKind tells what kind of place a location ID refers to
+type LocationKind uint8
+
+const (
+	LocationUnknown LocationKind = iota
+	// A city or portal town market, e.g. 3008
+	LocationMarket
+	// The black market, e.g. BLACKBANK-3003
+	LocationBlackMarket
+	// A smugglers den, e.g. 4000-HellDen
+	LocationHellDen
+	// A second auction house of a city, e.g. 3013-Auction2
+	LocationAuction
+	// A rest in the outlands, e.g. 4300@REST-1
+	LocationRest
+	// A player island, e.g. @ISLAND@<uuid>
+	LocationIsland
+)
+
+func (kind LocationKind) String() string {
+	names := [...]string{
+		"Unknown",
+		"Market",
+		"BlackMarket",
+		"HellDen",
+		"Auction",
+		"Rest",
+		"Island"}
+
+	if int(kind) >= len(names) {
+		return fmt.Sprintf("LocationKind(%d)", kind)
+	}
+
+	return names[kind]
+}
+
+// worldLocation is a location in the world data
+type worldLocation struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	City string `json:"city"`
+}
+
+var worldLocations = parseWorldData(worldData)
+
+func parseWorldData(data []byte) map[string]worldLocation {
+	var world struct {
+		Locations []worldLocation `json:"locations"`
+	}
+	if err := json.Unmarshal(data, &world); err != nil {
+		panic(fmt.Sprintf("invalid world data: %v", err))
+	}
+
+	locations := make(map[string]worldLocation, len(world.Locations))
+	for _, location := range world.Locations {
+		locations[location.ID] = location
+	}
+	return locations
+}
+
+var clusterID = regexp.MustCompile(`^[0-9]+$`)
+
+// Location is a location ID as the game sends it, along with what it refers to
+type Location struct {
+	// The raw location ID, this is what the ingest expects
+	ID   string
+	Kind LocationKind
+	// The numeric cluster the location belongs to, if there is one
+	Cluster string
+}
+
+// ParseLocation tells the kind and cluster of a location ID. IDs it does not
+// recognize are kept as they are with LocationUnknown.
+func ParseLocation(id string) Location {
+	location := Location{ID: id}
+
+	switch {
+	case clusterID.MatchString(id):
+		location.Kind = LocationMarket
+		location.Cluster = id
+	case strings.HasPrefix(id, "BLACKBANK-"):
+		location.Kind = LocationBlackMarket
+		location.Cluster = strings.TrimPrefix(id, "BLACKBANK-")
+	case strings.HasSuffix(id, "-HellDen"):
+		location.Kind = LocationHellDen
+		location.Cluster = strings.TrimSuffix(id, "-HellDen")
+	case strings.HasSuffix(id, "-Auction2"):
+		location.Kind = LocationAuction
+		location.Cluster = strings.TrimSuffix(id, "-Auction2")
+	case strings.HasPrefix(id, "@ISLAND@"):
+		location.Kind = LocationIsland
+	case strings.Contains(id, "@"):
+		location.Kind = LocationRest
+		location.Cluster = id[:strings.Index(id, "@")]
+	}
+
+	return location
+}
+
+// IsMarket tells whether orders can be seen from the location, this is where
+// the player needs to be for an upload to be trusted
+func (l Location) IsMarket() bool {
+	switch l.Kind {
+	case LocationMarket, LocationBlackMarket, LocationHellDen, LocationAuction:
+		return true
+	default:
+		return false
+	}
+}
+
+// City returns the name of the city the location belongs to, or an empty
+// string if it is not near a known city
+func (l Location) City() string {
+	return worldLocations[l.Cluster].City
+}
+
+// Name returns a name for the location that the player recognizes. It falls
+// back to the raw ID for locations not in the world data.
+func (l Location) Name() string {
+	if l.ID == "" {
+		return "an unknown location"
+	}
+
+	known, ok := worldLocations[l.Cluster]
+
+	switch {
+	case l.Kind == LocationMarket && ok:
+		return known.Name
+	case l.Kind == LocationBlackMarket:
+		return "Black Market"
+	case l.Kind == LocationHellDen && ok:
+		return known.City + " Smugglers Den"
+	case l.Kind == LocationAuction && ok:
+		return known.Name
+	case l.Kind == LocationRest && ok:
+		return known.City + " Rest"
+	case l.Kind == LocationIsland:
+		return "Player Island"
+	default:
+		return l.ID
+	}
+}
+
+// String returns the name with the raw ID, e.g. "Martlock Market (3008)"
+func (l Location) String() string {
+	name := l.Name()
+	if name == l.ID || l.ID == "" {
+		return name
+	}
+	return fmt.Sprintf("%s (%s)", name, l.ID)
+}
+
+// MarshalJSON writes the location with its resolved names, for local consumers
+func (l Location) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		ID   string `json:"Id"`
+		Kind string `json:"Kind"`
+		Name string `json:"Name"`
+		City string `json:"City,omitempty"`
+	}{l.ID, l.Kind.String(), l.Name(), l.City()})
+}
+
+// UnmarshalJSON reads a location from its object form or a bare ID
+func (l *Location) UnmarshalJSON(data []byte) error {
+	var id string
+	if err := json.Unmarshal(data, &id); err != nil {
+		var object struct {
+			ID string `json:"Id"`
+		}
+		if err := json.Unmarshal(data, &object); err != nil {
+			return err
+		}
+		id = object.ID
+	}
+
+	*l = ParseLocation(id)
+	return nil
+}
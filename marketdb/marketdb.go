@@ -0,0 +1,525 @@
+// Package marketdb is a local store of the market data the client captured:
+// orders, price histories and gold prices, indexed by item, location and
+// quality. The client writes to it through its marketdb:// uploader, other
+// tools open the same directory to query it.
+//
+// The store is a journal of JSON lines replayed into memory on open. Records
+// past their retention are dropped when the journal is compacted, which
+// happens on its own once it holds mostly replaced or expired records.
+//
+// One process writes to a store, any number may open it read only. Those
+// follow the journal: every query first reads what was appended since, and
+// reads it again from the start after a compaction replaced it. They only
+// keep the journal open while reading it, so the writer can replace it.
+package marketdb
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/ao-data/albiondata-client/lib"
+)
+
+// JournalName is the file of the store in its directory
+const JournalName = "marketdb.jsonl"
+
+const (
+	DefaultOrderRetention   = 72 * time.Hour
+	DefaultHistoryRetention = 90 * 24 * time.Hour
+	DefaultGoldRetention    = 90 * 24 * time.Hour
+
+	// compaction waits for at least this many stale records
+	minCompactStale = 10000
+)
+
+var (
+	// ErrReadOnly is returned by the writes to a store opened read only
+	ErrReadOnly = errors.New("marketdb: opened read only")
+	// ErrInvalid is returned for data that can never be stored
+	ErrInvalid = errors.New("marketdb: invalid data")
+)
+
+// Options are the retention policies of a store, zero values take the
+// defaults
+type Options struct {
+	// Orders not seen again for this long are dropped, as are expired ones
+	OrderRetention time.Duration
+	// Histories and gold prices older than this are dropped
+	HistoryRetention time.Duration
+	GoldRetention    time.Duration
+
+	// Open for queries only, the journal is not written to
+	ReadOnly bool
+}
+
+func (o *Options) defaults() {
+	if o.OrderRetention <= 0 {
+		o.OrderRetention = DefaultOrderRetention
+	}
+	if o.HistoryRetention <= 0 {
+		o.HistoryRetention = DefaultHistoryRetention
+	}
+	if o.GoldRetention <= 0 {
+		o.GoldRetention = DefaultGoldRetention
+	}
+}
+
+// Order is a market order as last seen
+type Order struct {
+	lib.MarketOrder
+	Realm  int       `json:"Realm"`
+	SeenAt time.Time `json:"SeenAt"`
+}
+
+// HistoryPoint is one entry of the price history of an item
+type HistoryPoint struct {
+	lib.MarketHistory
+	Realm      int           `json:"Realm"`
+	AlbionID   int32         `json:"AlbionId"`
+	LocationID string        `json:"LocationId"`
+	Quality    uint8         `json:"QualityLevel"`
+	Timescale  lib.Timescale `json:"Timescale"`
+	SeenAt     time.Time     `json:"SeenAt"`
+}
+
+// GoldPrice is the price of gold at a time
+type GoldPrice struct {
+	Realm     int       `json:"Realm"`
+	Price     int       `json:"Price"`
+	Timestamp int64     `json:"Timestamp"`
+	SeenAt    time.Time `json:"SeenAt"`
+}
+
+// record is a line of the journal
+type record struct {
+	Order   *Order        `json:"Order,omitempty"`
+	History *HistoryPoint `json:"History,omitempty"`
+	Gold    *GoldPrice    `json:"Gold,omitempty"`
+}
+
+type orderKey struct {
+	realm int
+	id    int
+}
+
+type historyKey struct {
+	realm     int
+	albionID  int32
+	location  string
+	quality   uint8
+	timescale lib.Timescale
+	timestamp uint64
+}
+
+type goldKey struct {
+	realm     int
+	timestamp int64
+}
+
+// DB is an open store
+type DB struct {
+	dir  string
+	opts Options
+
+	mu      sync.RWMutex
+	journal *os.File
+	// records in the journal, live or not
+	written int
+	// the journal replayed and how far, to follow it when read only
+	replayed os.FileInfo
+	offset   int64
+
+	orders           map[orderKey]*Order
+	ordersByItem     index[string, orderKey]
+	ordersByLocation index[string, orderKey]
+	ordersByQuality  index[int, orderKey]
+	histories        map[historyKey]*HistoryPoint
+	historiesByItem  index[int32, historyKey]
+	historiesByPlace index[string, historyKey]
+	historiesByGrade index[uint8, historyKey]
+	gold             map[goldKey]*GoldPrice
+}
+
+// Open opens the store in dir, creating it if needed
+func Open(dir string, opts Options) (*DB, error) {
+	opts.defaults()
+	db := &DB{dir: dir, opts: opts}
+	db.reset()
+
+	if !opts.ReadOnly {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return nil, err
+		}
+	}
+	if err := db.replay(); err != nil {
+		return nil, err
+	}
+	if opts.ReadOnly {
+		return db, nil
+	}
+
+	journal, err := os.OpenFile(db.path(), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, err
+	}
+	db.journal = journal
+	return db, nil
+}
+
+func (db *DB) path() string {
+	return filepath.Join(db.dir, JournalName)
+}
+
+// reset forgets everything replayed
+func (db *DB) reset() {
+	db.orders = make(map[orderKey]*Order)
+	db.ordersByItem = make(index[string, orderKey])
+	db.ordersByLocation = make(index[string, orderKey])
+	db.ordersByQuality = make(index[int, orderKey])
+	db.histories = make(map[historyKey]*HistoryPoint)
+	db.historiesByItem = make(index[int32, historyKey])
+	db.historiesByPlace = make(index[string, historyKey])
+	db.historiesByGrade = make(index[uint8, historyKey])
+	db.gold = make(map[goldKey]*GoldPrice)
+	db.written = 0
+	db.replayed = nil
+	db.offset = 0
+}
+
+// replay loads the journal from where the last replay stopped, or from the
+// start if it was replaced since. A torn last line, from a crash or a write
+// in progress by another process, is left for the next replay.
+func (db *DB) replay() error {
+	file, err := os.Open(db.path())
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+	if db.replayed != nil && (!os.SameFile(db.replayed, info) || info.Size() < db.offset) {
+		db.reset()
+	}
+	db.replayed = info
+	if _, err := file.Seek(db.offset, io.SeekStart); err != nil {
+		return err
+	}
+
+	reader := bufio.NewReaderSize(file, 64*1024)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("marketdb: reading %v: %v", db.path(), err)
+		}
+		db.offset += int64(len(line))
+
+		var r record
+		if err := json.Unmarshal(line, &r); err != nil {
+			continue
+		}
+		db.apply(r)
+		db.written++
+	}
+
+	db.expire(time.Now())
+	return nil
+}
+
+// Refresh reads what another process wrote to a store opened read only since
+// it was last read. The queries do it on their own.
+func (db *DB) Refresh() error {
+	if !db.opts.ReadOnly {
+		return nil
+	}
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	return db.replay()
+}
+
+// follow refreshes a store opened read only before a query. If that fails,
+// the query answers from what was read before.
+func (db *DB) follow() {
+	if db.opts.ReadOnly {
+		db.Refresh()
+	}
+}
+
+// apply puts a record in memory, replacing the one with the same key
+func (db *DB) apply(r record) {
+	switch {
+	case r.Order != nil:
+		o := r.Order
+		key := orderKey{realm: o.Realm, id: o.ID}
+		db.removeOrder(key)
+		db.orders[key] = o
+		db.ordersByItem.add(o.ItemID, key)
+		db.ordersByLocation.add(o.LocationID, key)
+		db.ordersByQuality.add(o.QualityLevel, key)
+	case r.History != nil:
+		h := r.History
+		key := historyKey{h.Realm, h.AlbionID, h.LocationID, h.Quality, h.Timescale, h.Timestamp}
+		db.histories[key] = h
+		db.historiesByItem.add(h.AlbionID, key)
+		db.historiesByPlace.add(h.LocationID, key)
+		db.historiesByGrade.add(h.Quality, key)
+	case r.Gold != nil:
+		db.gold[goldKey{r.Gold.Realm, r.Gold.Timestamp}] = r.Gold
+	}
+}
+
+func (db *DB) removeOrder(key orderKey) {
+	o, ok := db.orders[key]
+	if !ok {
+		return
+	}
+	delete(db.orders, key)
+	db.ordersByItem.remove(o.ItemID, key)
+	db.ordersByLocation.remove(o.LocationID, key)
+	db.ordersByQuality.remove(o.QualityLevel, key)
+}
+
+func (db *DB) removeHistory(key historyKey) {
+	h, ok := db.histories[key]
+	if !ok {
+		return
+	}
+	delete(db.histories, key)
+	db.historiesByItem.remove(h.AlbionID, key)
+	db.historiesByPlace.remove(h.LocationID, key)
+	db.historiesByGrade.remove(h.Quality, key)
+}
+
+// expire drops the records past their retention
+func (db *DB) expire(now time.Time) {
+	for key, o := range db.orders {
+		if !db.orderLive(o, now) {
+			db.removeOrder(key)
+		}
+	}
+	for key, h := range db.histories {
+		if now.Sub(lib.GameTime(int64(h.Timestamp))) > db.opts.HistoryRetention {
+			db.removeHistory(key)
+		}
+	}
+	for key, g := range db.gold {
+		if now.Sub(lib.GameTime(g.Timestamp)) > db.opts.GoldRetention {
+			delete(db.gold, key)
+		}
+	}
+}
+
+func (db *DB) orderLive(o *Order, now time.Time) bool {
+	if now.Sub(o.SeenAt) > db.opts.OrderRetention {
+		return false
+	}
+	if expires, ok := parseExpires(o.Expires); ok && expires.Before(now) {
+		return false
+	}
+	return true
+}
+
+// AddOrders stores orders of a realm, replacing what was known of them
+func (db *DB) AddOrders(realm int, orders []*lib.MarketOrder, seen time.Time) error {
+	records := make([]record, 0, len(orders))
+	for _, o := range orders {
+		if o == nil {
+			continue
+		}
+		records = append(records, record{Order: &Order{MarketOrder: *o, Realm: realm, SeenAt: seen}})
+	}
+	return db.write(records)
+}
+
+// AddHistories stores the price history of an item
+func (db *DB) AddHistories(realm int, upload *lib.MarketHistoriesUpload, seen time.Time) error {
+	records := make([]record, 0, len(upload.Histories))
+	for _, h := range upload.Histories {
+		if h == nil {
+			continue
+		}
+		records = append(records, record{History: &HistoryPoint{
+			MarketHistory: *h,
+			Realm:         realm,
+			AlbionID:      upload.AlbionId,
+			LocationID:    upload.LocationId,
+			Quality:       upload.QualityLevel,
+			Timescale:     upload.Timescale,
+			SeenAt:        seen,
+		}})
+	}
+	return db.write(records)
+}
+
+// AddGoldPrices stores gold prices of a realm
+func (db *DB) AddGoldPrices(realm int, upload *lib.GoldPricesUpload, seen time.Time) error {
+	if len(upload.Prices) != len(upload.TimeStamps) {
+		return fmt.Errorf("%w: %d gold prices with %d timestamps", ErrInvalid, len(upload.Prices), len(upload.TimeStamps))
+	}
+	records := make([]record, 0, len(upload.Prices))
+	for i, price := range upload.Prices {
+		records = append(records, record{Gold: &GoldPrice{Realm: realm, Price: price, Timestamp: upload.TimeStamps[i], SeenAt: seen}})
+	}
+	return db.write(records)
+}
+
+// write appends records to the journal and applies them
+func (db *DB) write(records []record) error {
+	if db.opts.ReadOnly {
+		return ErrReadOnly
+	}
+	if len(records) == 0 {
+		return nil
+	}
+
+	var buf []byte
+	for _, r := range records {
+		line, err := json.Marshal(r)
+		if err != nil {
+			return err
+		}
+		buf = append(append(buf, line...), '\n')
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if db.journal == nil {
+		return os.ErrClosed
+	}
+	if _, err := db.journal.Write(buf); err != nil {
+		return fmt.Errorf("marketdb: writing journal: %v", err)
+	}
+	for _, r := range records {
+		db.apply(r)
+	}
+	db.written += len(records)
+
+	if stale := db.written - db.live(); stale > minCompactStale && stale > db.live() {
+		return db.compact(time.Now())
+	}
+	return nil
+}
+
+func (db *DB) live() int {
+	return len(db.orders) + len(db.histories) + len(db.gold)
+}
+
+// Compact drops the records past their retention and rewrites the journal
+// with only the live ones
+func (db *DB) Compact() error {
+	if db.opts.ReadOnly {
+		return ErrReadOnly
+	}
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	if db.journal == nil {
+		return os.ErrClosed
+	}
+	return db.compact(time.Now())
+}
+
+func (db *DB) compact(now time.Time) error {
+	db.expire(now)
+
+	tmp := db.path() + ".tmp"
+	file, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(file)
+	encoder := json.NewEncoder(w)
+
+	var werr error
+	for _, o := range db.orders {
+		werr = errors.Join(werr, encoder.Encode(record{Order: o}))
+	}
+	for _, h := range db.histories {
+		werr = errors.Join(werr, encoder.Encode(record{History: h}))
+	}
+	for _, g := range db.gold {
+		werr = errors.Join(werr, encoder.Encode(record{Gold: g}))
+	}
+	werr = errors.Join(werr, w.Flush(), file.Sync(), file.Close())
+	if werr != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("marketdb: compacting: %v", werr)
+	}
+
+	if err := os.Rename(tmp, db.path()); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("marketdb: compacting: %v", err)
+	}
+
+	journal, err := os.OpenFile(db.path(), os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	db.journal.Close()
+	db.journal = journal
+	db.written = db.live()
+	return nil
+}
+
+// Close compacts and closes the store
+func (db *DB) Close() error {
+	if db.opts.ReadOnly {
+		return nil
+	}
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	if db.journal == nil {
+		return nil
+	}
+
+	err := db.compact(time.Now())
+	err = errors.Join(err, db.journal.Close())
+	db.journal = nil
+	return err
+}
+
+// index maps a field value to the keys of the records having it
+type index[V comparable, K comparable] map[V]map[K]struct{}
+
+func (i index[V, K]) add(value V, key K) {
+	keys, ok := i[value]
+	if !ok {
+		keys = make(map[K]struct{})
+		i[value] = keys
+	}
+	keys[key] = struct{}{}
+}
+
+func (i index[V, K]) remove(value V, key K) {
+	if keys, ok := i[value]; ok {
+		delete(keys, key)
+		if len(keys) == 0 {
+			delete(i, value)
+		}
+	}
+}
+
+// parseExpires reads the expiry of an order
+func parseExpires(value string) (time.Time, bool) {
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02T15:04:05.999999999", "2006-01-02T15:04:05"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,271 @@
+package marketdb
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ao-data/albiondata-client/lib"
+)
+
+// ticks converts a time to the .NET ticks the game sends
+func ticks(t time.Time) int64 {
+	return t.UnixNano()/100 + 621355968000000000
+}
+
+func order(id, price int) *lib.MarketOrder {
+	return &lib.MarketOrder{
+		ID:           id,
+		ItemID:       "T4_BAG",
+		LocationID:   "3005",
+		QualityLevel: 1,
+		Price:        price,
+		Amount:       1,
+		AuctionType:  "offer",
+	}
+}
+
+func openTest(t *testing.T, dir string, opts Options) *DB {
+	t.Helper()
+
+	db, err := Open(dir, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func TestReplay(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now()
+
+	db := openTest(t, dir, Options{})
+	if err := db.AddOrders(1, []*lib.MarketOrder{order(1, 300), order(2, 100)}, now); err != nil {
+		t.Fatal(err)
+	}
+	// replaces the first order
+	if err := db.AddOrders(1, []*lib.MarketOrder{order(1, 200)}, now); err != nil {
+		t.Fatal(err)
+	}
+	gold := &lib.GoldPricesUpload{
+		Prices:     []int{4000, 4100},
+		TimeStamps: []int64{ticks(now.Add(-2 * time.Hour)), ticks(now.Add(-time.Hour))},
+	}
+	if err := db.AddGoldPrices(1, gold, now); err != nil {
+		t.Fatal(err)
+	}
+	histories := &lib.MarketHistoriesUpload{
+		AlbionId:     1234,
+		LocationId:   "3005",
+		QualityLevel: 1,
+		Histories: []*lib.MarketHistory{
+			{ItemAmount: 1, SilverAmount: 100, Timestamp: uint64(ticks(now.Add(-48 * time.Hour)))},
+			{ItemAmount: 2, SilverAmount: 200, Timestamp: uint64(ticks(now.Add(-24 * time.Hour)))},
+		},
+	}
+	if err := db.AddHistories(1, histories, now); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.journal.Sync(); err != nil {
+		t.Fatal(err)
+	}
+
+	// read by another process while the first one is still running
+	replayed := openTest(t, dir, Options{ReadOnly: true})
+	orders := replayed.Orders(Query{Item: "T4_BAG"})
+	if len(orders) != 2 || orders[0].ID != 2 || orders[1].ID != 1 || orders[1].Price != 200 {
+		t.Errorf("replayed orders %+v, want 2 then 1 at 200", orders)
+	}
+	if prices := replayed.GoldPrices(1, time.Time{}); len(prices) != 2 || prices[0].Price != 4000 {
+		t.Errorf("replayed gold prices %+v, want 4000 then 4100", prices)
+	}
+	if points := replayed.Histories(Query{AlbionID: 1234}); len(points) != 2 || points[0].SilverAmount != 200 {
+		t.Errorf("replayed histories %+v, want the newest first", points)
+	}
+	if err := replayed.AddOrders(1, []*lib.MarketOrder{order(3, 1)}, now); err != ErrReadOnly {
+		t.Errorf("a write to a read only store got %v, want ErrReadOnly", err)
+	}
+}
+
+func TestReplayDropsExpired(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now()
+
+	db := openTest(t, dir, Options{})
+	if err := db.AddOrders(1, []*lib.MarketOrder{order(1, 100)}, now.Add(-time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.AddOrders(1, []*lib.MarketOrder{order(2, 100)}, now); err != nil {
+		t.Fatal(err)
+	}
+
+	replayed := openTest(t, dir, Options{OrderRetention: 30 * time.Minute, ReadOnly: true})
+	if orders := replayed.Orders(Query{}); len(orders) != 1 || orders[0].ID != 2 {
+		t.Errorf("replayed orders %+v, want only the one seen since the retention", orders)
+	}
+}
+
+func TestReplaySkipsTornLines(t *testing.T) {
+	dir := t.TempDir()
+
+	db := openTest(t, dir, Options{})
+	if err := db.AddOrders(1, []*lib.MarketOrder{order(1, 100)}, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	journal, err := os.OpenFile(filepath.Join(dir, JournalName), os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer journal.Close()
+	// a line being written, and a broken one before it
+	if _, err := journal.WriteString("not json\n{\"Order\":{\"Id\":2,"); err != nil {
+		t.Fatal(err)
+	}
+
+	reader := openTest(t, dir, Options{ReadOnly: true})
+	if orders, _, _ := reader.Stats(); orders != 1 {
+		t.Fatalf("replayed %d orders, want the 1 complete", orders)
+	}
+
+	// the rest of the line arrives
+	seen := time.Now().UTC().Format(time.RFC3339Nano)
+	if _, err := journal.WriteString(`"ItemTypeId":"T4_BAG","Realm":1,"SeenAt":"` + seen + "\"}}\n"); err != nil {
+		t.Fatal(err)
+	}
+	if orders := reader.Orders(Query{}); len(orders) != 2 {
+		t.Errorf("got %d orders once the line was complete, want 2", len(orders))
+	}
+}
+
+func TestCompaction(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, JournalName)
+	now := time.Now()
+
+	db := openTest(t, dir, Options{OrderRetention: time.Hour})
+	for price := 1; price <= 10; price++ {
+		if err := db.AddOrders(1, []*lib.MarketOrder{order(1, price)}, now); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := db.AddOrders(1, []*lib.MarketOrder{order(2, 100)}, now.Add(-2*time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	before, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := db.Compact(); err != nil {
+		t.Fatal(err)
+	}
+	after, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if after.Size() >= before.Size() {
+		t.Errorf("the journal went from %d to %d bytes", before.Size(), after.Size())
+	}
+	if db.written != 1 {
+		t.Errorf("the compacted journal holds %d records, want 1", db.written)
+	}
+
+	// still appended to after the compaction
+	if err := db.AddOrders(1, []*lib.MarketOrder{order(3, 50)}, now); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	replayed := openTest(t, dir, Options{OrderRetention: time.Hour, ReadOnly: true})
+	orders := replayed.Orders(Query{})
+	if len(orders) != 2 || orders[0].ID != 1 || orders[0].Price != 10 || orders[1].ID != 3 {
+		t.Errorf("replayed orders %+v, want 1 at 10 then 3", orders)
+	}
+}
+
+// A reader follows the writes of another process, through compactions
+func TestReaderFollowsTheJournal(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now()
+
+	db := openTest(t, dir, Options{})
+	if err := db.AddOrders(1, []*lib.MarketOrder{order(1, 100)}, now); err != nil {
+		t.Fatal(err)
+	}
+	reader := openTest(t, dir, Options{ReadOnly: true})
+	if orders := reader.Orders(Query{}); len(orders) != 1 {
+		t.Fatalf("got %d orders, want 1", len(orders))
+	}
+
+	if err := db.AddOrders(1, []*lib.MarketOrder{order(2, 200)}, now); err != nil {
+		t.Fatal(err)
+	}
+	if orders := reader.Orders(Query{}); len(orders) != 2 {
+		t.Errorf("got %d orders after a write, want 2", len(orders))
+	}
+
+	// the compacted journal is a new, shorter file with different orders
+	if err := db.AddOrders(1, []*lib.MarketOrder{order(1, 100), order(2, 200)}, now.Add(-100*time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Compact(); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.AddOrders(1, []*lib.MarketOrder{order(3, 300)}, now); err != nil {
+		t.Fatal(err)
+	}
+	if orders := reader.Orders(Query{}); len(orders) != 1 || orders[0].ID != 3 {
+		t.Errorf("got orders %+v after the compaction, want only 3", orders)
+	}
+}
+
+func TestConcurrentReader(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now()
+
+	db := openTest(t, dir, Options{})
+	if err := db.AddOrders(1, []*lib.MarketOrder{order(0, 1)}, now); err != nil {
+		t.Fatal(err)
+	}
+	reader := openTest(t, dir, Options{ReadOnly: true})
+
+	const writes = 200
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 1; i <= writes; i++ {
+			if err := db.AddOrders(1, []*lib.MarketOrder{order(i, i)}, now); err != nil {
+				t.Error(err)
+				return
+			}
+			if i%50 == 0 {
+				if err := db.Compact(); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}
+	}()
+
+	// the reader only ever sees more orders, never a torn or emptied journal
+	seen := 0
+	deadline := time.Now().Add(10 * time.Second)
+	for seen < writes+1 && time.Now().Before(deadline) {
+		orders, _, _ := reader.Stats()
+		if orders < seen {
+			t.Fatalf("the reader went from %d orders to %d", seen, orders)
+		}
+		seen = orders
+	}
+	wg.Wait()
+
+	if got := reader.Orders(Query{}); len(got) != writes+1 {
+		t.Errorf("the reader ended with %d orders, want %d", len(got), writes+1)
+	}
+}
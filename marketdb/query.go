@@ -0,0 +1,166 @@
+package marketdb
+
+import (
+	"sort"
+	"time"
+
+	"github.com/ao-data/albiondata-client/lib"
+)
+
+// Query selects records, zero fields match everything
+type Query struct {
+	Realm int
+	// The item of orders, e.g. T4_BAG
+	Item string
+	// The item of histories, the game sends those by numeric id
+	AlbionID int32
+	Location string
+	Quality  int
+	// Only records seen since
+	Since time.Time
+}
+
+func (q Query) matchesOrder(o *Order) bool {
+	return (q.Realm == 0 || o.Realm == q.Realm) &&
+		(q.Item == "" || o.ItemID == q.Item) &&
+		(q.Location == "" || o.LocationID == q.Location) &&
+		(q.Quality == 0 || o.QualityLevel == q.Quality) &&
+		!o.SeenAt.Before(q.Since)
+}
+
+func (q Query) matchesHistory(h *HistoryPoint) bool {
+	return (q.Realm == 0 || h.Realm == q.Realm) &&
+		(q.AlbionID == 0 || h.AlbionID == q.AlbionID) &&
+		(q.Location == "" || h.LocationID == q.Location) &&
+		(q.Quality == 0 || int(h.Quality) == q.Quality) &&
+		!h.SeenAt.Before(q.Since)
+}
+
+// smallest returns the smallest of the candidate key sets, nil when the
+// query has no indexed field and every record is a candidate
+func smallest[K comparable](sets ...map[K]struct{}) (map[K]struct{}, bool) {
+	var best map[K]struct{}
+	found := false
+	for _, set := range sets {
+		if set == nil {
+			continue
+		}
+		if !found || len(set) < len(best) {
+			best, found = set, true
+		}
+	}
+	return best, found
+}
+
+// candidates are the keys of an index for a value, an empty set when no
+// record has it and nil when the field is not queried
+func candidates[V comparable, K comparable](i index[V, K], value V, queried bool) map[K]struct{} {
+	if !queried {
+		return nil
+	}
+	if keys, ok := i[value]; ok {
+		return keys
+	}
+	return map[K]struct{}{}
+}
+
+// Orders returns the live orders matching q, cheapest first
+func (db *DB) Orders(q Query) []Order {
+	db.follow()
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	now := time.Now()
+	var result []Order
+	add := func(o *Order) {
+		if q.matchesOrder(o) && db.orderLive(o, now) {
+			result = append(result, *o)
+		}
+	}
+
+	keys, indexed := smallest(
+		candidates(db.ordersByItem, q.Item, q.Item != ""),
+		candidates(db.ordersByLocation, q.Location, q.Location != ""),
+		candidates(db.ordersByQuality, q.Quality, q.Quality != 0),
+	)
+	if indexed {
+		for key := range keys {
+			add(db.orders[key])
+		}
+	} else {
+		for _, o := range db.orders {
+			add(o)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Price != result[j].Price {
+			return result[i].Price < result[j].Price
+		}
+		return result[i].ID < result[j].ID
+	})
+	return result
+}
+
+// Histories returns the history points matching q, newest first
+func (db *DB) Histories(q Query) []HistoryPoint {
+	db.follow()
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	now := time.Now()
+	var result []HistoryPoint
+	add := func(h *HistoryPoint) {
+		if q.matchesHistory(h) && now.Sub(lib.GameTime(int64(h.Timestamp))) <= db.opts.HistoryRetention {
+			result = append(result, *h)
+		}
+	}
+
+	keys, indexed := smallest(
+		candidates(db.historiesByItem, q.AlbionID, q.AlbionID != 0),
+		candidates(db.historiesByPlace, q.Location, q.Location != ""),
+		candidates(db.historiesByGrade, uint8(q.Quality), q.Quality != 0),
+	)
+	if indexed {
+		for key := range keys {
+			add(db.histories[key])
+		}
+	} else {
+		for _, h := range db.histories {
+			add(h)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Timestamp > result[j].Timestamp
+	})
+	return result
+}
+
+// GoldPrices returns the gold prices of a realm, all realms for 0, since a
+// time, oldest first
+func (db *DB) GoldPrices(realm int, since time.Time) []GoldPrice {
+	db.follow()
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	var result []GoldPrice
+	for _, g := range db.gold {
+		if (realm == 0 || g.Realm == realm) && !lib.GameTime(g.Timestamp).Before(since) {
+			result = append(result, *g)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Timestamp < result[j].Timestamp
+	})
+	return result
+}
+
+// Stats counts the live records by kind
+func (db *DB) Stats() (orders, histories, gold int) {
+	db.follow()
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+	return len(db.orders), len(db.histories), len(db.gold)
+}
@@ -0,0 +1,326 @@
+package mockingest
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/ao-data/albiondata-client/log"
+)
+
+// Message is a message published to the MQTT broker stand-in
+type Message struct {
+	ClientID   string
+	Topic      string
+	Payload    []byte
+	QoS        byte
+	Retain     bool
+	ReceivedAt time.Time
+}
+
+// MQTTBroker is an MQTT 3.1.1 broker stand-in, enough for publishers: it
+// accepts connections, acknowledges QoS 1 publishes, keeps the retained
+// messages and answers pings. It delivers nothing to subscribers, the
+// published messages are kept for assertions instead.
+type MQTTBroker struct {
+	// The credentials clients must connect with, none when empty
+	Username string
+	Password string
+
+	listener net.Listener
+
+	mu       sync.Mutex
+	conns    map[net.Conn]bool
+	messages []Message
+	retained map[string]Message
+	connects int
+	arrived  chan struct{}
+}
+
+// NewMQTTBroker starts a broker listening on addr, e.g. 127.0.0.1:0
+func NewMQTTBroker(addr string) (*MQTTBroker, error) {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+
+	b := &MQTTBroker{
+		listener: listener,
+		conns:    make(map[net.Conn]bool),
+		retained: make(map[string]Message),
+		arrived:  make(chan struct{}),
+	}
+	go b.accept()
+	return b, nil
+}
+
+// Addr is the address the broker listens on
+func (b *MQTTBroker) Addr() string {
+	return b.listener.Addr().String()
+}
+
+// Close stops the broker and drops its connections
+func (b *MQTTBroker) Close() error {
+	err := b.listener.Close()
+	b.DropConnections()
+	return err
+}
+
+// DropConnections closes the connections of all clients, to test that they
+// connect again
+func (b *MQTTBroker) DropConnections() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for c := range b.conns {
+		c.Close()
+		delete(b.conns, c)
+	}
+}
+
+// Messages returns the messages published to a topic, all for ""
+func (b *MQTTBroker) Messages(topic string) []Message {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	messages := []Message{}
+	for _, m := range b.messages {
+		if topic == "" || m.Topic == topic {
+			messages = append(messages, m)
+		}
+	}
+	return messages
+}
+
+// Retained returns the retained message of a topic
+func (b *MQTTBroker) Retained(topic string) (Message, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	m, ok := b.retained[topic]
+	return m, ok
+}
+
+// Connects counts the accepted connections
+func (b *MQTTBroker) Connects() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.connects
+}
+
+// WaitFor waits until count messages of a topic arrived, or the timeout
+// passed. It returns the messages there are.
+func (b *MQTTBroker) WaitFor(topic string, count int, timeout time.Duration) ([]Message, bool) {
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
+
+	for {
+		b.mu.Lock()
+		arrived := b.arrived
+		b.mu.Unlock()
+
+		messages := b.Messages(topic)
+		if len(messages) >= count {
+			return messages, true
+		}
+
+		select {
+		case <-arrived:
+		case <-deadline.C:
+			return messages, false
+		}
+	}
+}
+
+func (b *MQTTBroker) accept() {
+	for {
+		c, err := b.listener.Accept()
+		if err != nil {
+			return
+		}
+		b.mu.Lock()
+		b.conns[c] = true
+		b.mu.Unlock()
+		go b.serve(c)
+	}
+}
+
+func (b *MQTTBroker) serve(c net.Conn) {
+	defer func() {
+		b.mu.Lock()
+		delete(b.conns, c)
+		b.mu.Unlock()
+		c.Close()
+	}()
+
+	r := bufio.NewReader(c)
+	header, body, err := readPacket(r)
+	if err != nil {
+		return
+	}
+	if header>>4 != 1 {
+		log.Warnf("MQTT client %v did not start with CONNECT", c.RemoteAddr())
+		return
+	}
+	clientID, code := b.checkConnect(body)
+	if _, err := c.Write([]byte{0x20, 2, 0, code}); err != nil || code != 0 {
+		return
+	}
+
+	b.mu.Lock()
+	b.connects++
+	b.mu.Unlock()
+
+	for {
+		header, body, err := readPacket(r)
+		if err != nil {
+			return
+		}
+
+		switch header >> 4 {
+		case 3: // PUBLISH
+			m, id, err := parsePublish(header, body)
+			if err != nil {
+				log.Warnf("MQTT client %v sent a bad PUBLISH: %v", clientID, err)
+				return
+			}
+			m.ClientID = clientID
+			b.store(m)
+			if m.QoS == 1 {
+				ack := binary.BigEndian.AppendUint16([]byte{0x40, 2}, id)
+				if _, err := c.Write(ack); err != nil {
+					return
+				}
+			}
+		case 12: // PINGREQ
+			if _, err := c.Write([]byte{0xd0, 0}); err != nil {
+				return
+			}
+		case 14: // DISCONNECT
+			return
+		default:
+			log.Warnf("MQTT client %v sent unsupported packet type %d", clientID, header>>4)
+			return
+		}
+	}
+}
+
+// checkConnect reads a CONNECT, returning the client id and the CONNACK code
+func (b *MQTTBroker) checkConnect(body []byte) (string, byte) {
+	protocol, rest, ok := readString(body)
+	if !ok || protocol != "MQTT" || len(rest) < 4 {
+		return "", 1
+	}
+	if rest[0] != 4 {
+		return "", 1
+	}
+	flags := rest[1]
+	rest = rest[4:]
+
+	clientID, rest, ok := readString(rest)
+	if !ok {
+		return "", 2
+	}
+	if flags&0x04 != 0 { // will topic and message
+		if _, rest, ok = readString(rest); !ok {
+			return "", 2
+		}
+		if _, rest, ok = readString(rest); !ok {
+			return "", 2
+		}
+	}
+	var username, password string
+	if flags&0x80 != 0 {
+		if username, rest, ok = readString(rest); !ok {
+			return "", 4
+		}
+	}
+	if flags&0x40 != 0 {
+		if password, _, ok = readString(rest); !ok {
+			return "", 4
+		}
+	}
+
+	if b.Username != "" && (username != b.Username || password != b.Password) {
+		return clientID, 4
+	}
+	return clientID, 0
+}
+
+func (b *MQTTBroker) store(m Message) {
+	m.ReceivedAt = time.Now()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.messages = append(b.messages, m)
+	if m.Retain {
+		if len(m.Payload) == 0 {
+			delete(b.retained, m.Topic)
+		} else {
+			b.retained[m.Topic] = m
+		}
+	}
+	close(b.arrived)
+	b.arrived = make(chan struct{})
+}
+
+func parsePublish(header byte, body []byte) (Message, uint16, error) {
+	m := Message{QoS: (header >> 1) & 0x03, Retain: header&0x01 != 0}
+	if m.QoS > 1 {
+		return m, 0, fmt.Errorf("qos %d is not supported", m.QoS)
+	}
+
+	topic, rest, ok := readString(body)
+	if !ok || topic == "" {
+		return m, 0, fmt.Errorf("no topic")
+	}
+	m.Topic = topic
+
+	var id uint16
+	if m.QoS > 0 {
+		if len(rest) < 2 {
+			return m, 0, fmt.Errorf("no packet id")
+		}
+		id = binary.BigEndian.Uint16(rest)
+		rest = rest[2:]
+	}
+	m.Payload = append([]byte(nil), rest...)
+	return m, id, nil
+}
+
+func readPacket(r *bufio.Reader) (byte, []byte, error) {
+	header, err := r.ReadByte()
+	if err != nil {
+		return 0, nil, err
+	}
+	length, shift := 0, 0
+	for {
+		digit, err := r.ReadByte()
+		if err != nil {
+			return 0, nil, err
+		}
+		length |= int(digit&0x7f) << shift
+		if digit&0x80 == 0 {
+			break
+		}
+		if shift += 7; shift > 21 {
+			return 0, nil, fmt.Errorf("malformed remaining length")
+		}
+	}
+	body := make([]byte, length)
+	_, err = io.ReadFull(r, body)
+	return header, body, err
+}
+
+func readString(b []byte) (string, []byte, bool) {
+	if len(b) < 2 {
+		return "", nil, false
+	}
+	n := int(binary.BigEndian.Uint16(b))
+	if len(b) < 2+n {
+		return "", nil, false
+	}
+	return string(b[2 : 2+n]), b[2+n:], true
+}
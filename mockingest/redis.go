@@ -0,0 +1,324 @@
+package mockingest
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ao-data/albiondata-client/log"
+)
+
+// StreamEntry is an entry added to a stream of the Redis stand-in
+type StreamEntry struct {
+	DB     int
+	Stream string
+	ID     string
+	Fields map[string]string
+}
+
+// RedisServer is a Redis stand-in speaking RESP, enough for stream producers:
+// it answers AUTH, SELECT, PING and XADD. The entries are kept for assertions.
+type RedisServer struct {
+	listener net.Listener
+
+	mu       sync.Mutex
+	username string
+	password string
+	conns    map[net.Conn]bool
+	entries  []StreamEntry
+	failure  string
+	connects int
+	sequence int
+}
+
+// NewRedisServer starts a server listening on addr, e.g. 127.0.0.1:0
+func NewRedisServer(addr string) (*RedisServer, error) {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &RedisServer{
+		listener: listener,
+		conns:    make(map[net.Conn]bool),
+	}
+	go s.accept()
+	return s, nil
+}
+
+// Addr is the address the server listens on
+func (s *RedisServer) Addr() string {
+	return s.listener.Addr().String()
+}
+
+// Close stops the server and drops its connections
+func (s *RedisServer) Close() error {
+	err := s.listener.Close()
+	s.DropConnections()
+	return err
+}
+
+// DropConnections closes the connections of all clients, to test that they
+// connect again
+func (s *RedisServer) DropConnections() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for c := range s.conns {
+		c.Close()
+		delete(s.conns, c)
+	}
+}
+
+// RequireAuth makes clients AUTH with the credentials before anything else.
+// AUTH with the password alone logs in as "default".
+func (s *RedisServer) RequireAuth(username, password string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.username = username
+	s.password = password
+}
+
+// FailWith makes XADD answer with an error reply, e.g. "LOADING ...", until
+// it is called with ""
+func (s *RedisServer) FailWith(reply string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failure = reply
+}
+
+// Entries returns the entries added to a stream, all for ""
+func (s *RedisServer) Entries(stream string) []StreamEntry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entries := []StreamEntry{}
+	for _, e := range s.entries {
+		if stream == "" || e.Stream == stream {
+			entries = append(entries, e)
+		}
+	}
+	return entries
+}
+
+// Connects counts the accepted connections
+func (s *RedisServer) Connects() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.connects
+}
+
+func (s *RedisServer) accept() {
+	for {
+		c, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		s.mu.Lock()
+		s.conns[c] = true
+		s.connects++
+		s.mu.Unlock()
+		go s.serve(c)
+	}
+}
+
+func (s *RedisServer) serve(c net.Conn) {
+	defer func() {
+		s.mu.Lock()
+		delete(s.conns, c)
+		s.mu.Unlock()
+		c.Close()
+	}()
+
+	s.mu.Lock()
+	authed := s.password == ""
+	s.mu.Unlock()
+
+	r := bufio.NewReader(c)
+	db := 0
+	for {
+		args, err := readCommand(r)
+		if err != nil {
+			if err != io.EOF && !errors.Is(err, net.ErrClosed) {
+				log.Warnf("Redis client %v sent a bad command: %v", c.RemoteAddr(), err)
+			}
+			return
+		}
+
+		var reply string
+		switch name := strings.ToUpper(args[0]); {
+		case name == "AUTH":
+			reply, authed = s.auth(args[1:])
+		case !authed:
+			reply = "-NOAUTH Authentication required.\r\n"
+		case name == "PING":
+			reply = "+PONG\r\n"
+		case name == "SELECT":
+			n, err := strconv.Atoi(argument(args, 1))
+			if err != nil || n < 0 || n > 15 {
+				reply = "-ERR DB index is out of range\r\n"
+				break
+			}
+			db = n
+			reply = "+OK\r\n"
+		case name == "XADD":
+			reply = s.xadd(db, args[1:])
+		default:
+			reply = fmt.Sprintf("-ERR unknown command '%s'\r\n", args[0])
+		}
+		if _, err := io.WriteString(c, reply); err != nil {
+			return
+		}
+	}
+}
+
+// auth checks AUTH [username] password
+func (s *RedisServer) auth(args []string) (string, bool) {
+	username, password := "default", ""
+	switch len(args) {
+	case 1:
+		password = args[0]
+	case 2:
+		username, password = args[0], args[1]
+	default:
+		return "-ERR wrong number of arguments for 'auth' command\r\n", false
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	want := s.username
+	if want == "" {
+		want = "default"
+	}
+	if s.password == "" || username != want || password != s.password {
+		return "-WRONGPASS invalid username-password pair or user is disabled.\r\n", false
+	}
+	return "+OK\r\n", true
+}
+
+// xadd adds an entry: XADD key [MAXLEN [~|=] n] * field value...
+func (s *RedisServer) xadd(db int, args []string) string {
+	if len(args) < 1 {
+		return "-ERR wrong number of arguments for 'xadd' command\r\n"
+	}
+	stream, args := args[0], args[1:]
+
+	maxLen := -1
+	if len(args) > 0 && strings.ToUpper(args[0]) == "MAXLEN" {
+		args = args[1:]
+		if len(args) > 0 && (args[0] == "~" || args[0] == "=") {
+			args = args[1:]
+		}
+		n, err := strconv.Atoi(argument(args, 0))
+		if err != nil || n < 0 {
+			return "-ERR syntax error\r\n"
+		}
+		maxLen = n
+		args = args[1:]
+	}
+	if argument(args, 0) != "*" {
+		return "-ERR only * ids are supported\r\n"
+	}
+	args = args[1:]
+	if len(args) == 0 || len(args)%2 != 0 {
+		return "-ERR wrong number of arguments for 'xadd' command\r\n"
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.failure != "" {
+		return "-" + s.failure + "\r\n"
+	}
+
+	s.sequence++
+	e := StreamEntry{
+		DB:     db,
+		Stream: stream,
+		ID:     fmt.Sprintf("%d-%d", time.Now().UnixMilli(), s.sequence),
+		Fields: make(map[string]string),
+	}
+	for i := 0; i < len(args); i += 2 {
+		e.Fields[args[i]] = args[i+1]
+	}
+	s.entries = append(s.entries, e)
+	if maxLen >= 0 {
+		s.trim(db, stream, maxLen)
+	}
+	return fmt.Sprintf("$%d\r\n%s\r\n", len(e.ID), e.ID)
+}
+
+// trim keeps the last maxLen entries of a stream
+func (s *RedisServer) trim(db int, stream string, maxLen int) {
+	count := 0
+	for _, e := range s.entries {
+		if e.DB == db && e.Stream == stream {
+			count++
+		}
+	}
+
+	kept := s.entries[:0]
+	for _, e := range s.entries {
+		if e.DB == db && e.Stream == stream && count > maxLen {
+			count--
+			continue
+		}
+		kept = append(kept, e)
+	}
+	s.entries = kept
+}
+
+func argument(args []string, i int) string {
+	if i < len(args) {
+		return args[i]
+	}
+	return ""
+}
+
+// readCommand reads a command sent as a RESP array of bulk strings
+func readCommand(r *bufio.Reader) ([]string, error) {
+	line, err := readLine(r)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasPrefix(line, "*") {
+		return nil, fmt.Errorf("expected an array, got %q", line)
+	}
+	n, err := strconv.Atoi(line[1:])
+	if err != nil || n < 1 {
+		return nil, fmt.Errorf("bad array length %q", line)
+	}
+
+	args := make([]string, n)
+	for i := range args {
+		line, err := readLine(r)
+		if err != nil {
+			return nil, err
+		}
+		if !strings.HasPrefix(line, "$") {
+			return nil, fmt.Errorf("expected a bulk string, got %q", line)
+		}
+		size, err := strconv.Atoi(line[1:])
+		if err != nil || size < 0 {
+			return nil, fmt.Errorf("bad bulk length %q", line)
+		}
+		data := make([]byte, size+2)
+		if _, err := io.ReadFull(r, data); err != nil {
+			return nil, err
+		}
+		args[i] = string(data[:size])
+	}
+	return args, nil
+}
+
+func readLine(r *bufio.Reader) (string, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(line, "\r\n"), nil
+}
@@ -0,0 +1,436 @@
+// Package mockingest is a stand-in for the Albion Data ingest, to run the
+// client's whole pipeline offline. It hands out and checks proofs of work,
+// validates the payloads against the upload structs in lib and keeps what it
+// received for assertions.
+package mockingest
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ao-data/albiondata-client/lib"
+	"github.com/ao-data/albiondata-client/log"
+)
+
+const (
+	// How long a challenge handed out by GET /pow may be answered
+	challengeTTL = 5 * time.Minute
+	// The largest body accepted, like the real ingest
+	maxBodySize = 10 << 20
+	// How far the timestamp of a signed upload may be off
+	signatureMaxSkew = 5 * time.Minute
+)
+
+// Payload is an upload the server accepted
+type Payload struct {
+	Topic      string          `json:"Topic"`
+	Body       json.RawMessage `json:"Body"`
+	ServerID   int             `json:"ServerId,omitempty"`
+	Identifier string          `json:"Identifier,omitempty"`
+	// Whether it came with a solved proof of work
+	Pow        bool      `json:"Pow"`
+	ReceivedAt time.Time `json:"ReceivedAt"`
+}
+
+type challenge struct {
+	wanted  string
+	expires time.Time
+}
+
+// Server implements the ingest endpoints:
+//
+//	GET  /pow          a challenge to solve
+//	POST /pow/{topic}  a payload with the solution of a challenge
+//	POST /{topic}      a payload without proof of work, signed if Secret is set
+//	GET  /received     the accepted payloads, ?topic= to filter
+//	DELETE /received   forgets them
+type Server struct {
+	// How many leading bits of the hash a solution must match
+	Difficulty int
+	// Called with every accepted payload, e.g. to store them
+	OnPayload func(Payload)
+	// The HMAC secret plain uploads must be signed with, none when empty
+	Secret string
+	// The bearer token plain uploads must carry, none when empty
+	Token string
+
+	mu         sync.Mutex
+	challenges map[string]challenge
+	received   []Payload
+	rejected   int
+	nonces     map[string]time.Time
+	arrived    chan struct{}
+}
+
+// NewServer creates a server asking for difficulty bits of proof of work
+func NewServer(difficulty int) *Server {
+	return &Server{
+		Difficulty: difficulty,
+		challenges: make(map[string]challenge),
+		nonces:     make(map[string]time.Time),
+		arrived:    make(chan struct{}),
+	}
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	path := strings.Trim(r.URL.Path, "/")
+
+	switch {
+	case path == "pow" && r.Method == http.MethodGet:
+		s.handleChallenge(w)
+	case strings.HasPrefix(path, "pow/") && r.Method == http.MethodPost:
+		s.handlePowUpload(w, r, strings.TrimPrefix(path, "pow/"))
+	case path == "received" && r.Method == http.MethodGet:
+		s.handleReceived(w, r)
+	case path == "received" && r.Method == http.MethodDelete:
+		s.Reset()
+	case path != "" && !strings.Contains(path, "/") && r.Method == http.MethodPost:
+		s.handleUpload(w, r, path)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (s *Server) handleChallenge(w http.ResponseWriter) {
+	key := randomHex(16)
+	// the solutions are matched against the bits of the hex encoded hash,
+	// so the wanted bits are taken from a hex string too
+	wanted := toBits(randomHex(32))[:s.difficulty()]
+
+	s.mu.Lock()
+	now := time.Now()
+	for k, c := range s.challenges {
+		if now.After(c.expires) {
+			delete(s.challenges, k)
+		}
+	}
+	s.challenges[key] = challenge{wanted: wanted, expires: now.Add(challengeTTL)}
+	s.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"key": key, "wanted": wanted})
+}
+
+func (s *Server) handlePowUpload(w http.ResponseWriter, r *http.Request, topic string) {
+	// the client posts the form without a content type, so it is not left
+	// to ParseForm
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBodySize))
+	if err != nil {
+		s.reject(w, http.StatusBadRequest, "could not read body: %v", err)
+		return
+	}
+	body, status, err := decodeBody(r.Header.Get("Content-Encoding"), body)
+	if err != nil {
+		s.reject(w, status, "%v", err)
+		return
+	}
+	form, err := url.ParseQuery(string(body))
+	if err != nil {
+		s.reject(w, http.StatusBadRequest, "invalid form: %v", err)
+		return
+	}
+
+	key := form.Get("key")
+	s.mu.Lock()
+	c, ok := s.challenges[key]
+	// a challenge is good for one upload
+	delete(s.challenges, key)
+	s.mu.Unlock()
+
+	if !ok || time.Now().After(c.expires) {
+		s.reject(w, http.StatusBadRequest, "unknown or expired pow key %q", key)
+		return
+	}
+	if solution := form.Get("solution"); !Solves(key, c.wanted, solution) {
+		s.reject(w, http.StatusBadRequest, "wrong pow solution %q", solution)
+		return
+	}
+
+	serverID, err := strconv.Atoi(form.Get("serverid"))
+	if err != nil {
+		s.reject(w, http.StatusBadRequest, "invalid serverid: %v", err)
+		return
+	}
+
+	s.accept(w, Payload{
+		Topic:      topic,
+		Body:       json.RawMessage(form.Get("natsmsg")),
+		ServerID:   serverID,
+		Identifier: form.Get("identifier"),
+		Pow:        true,
+	})
+}
+
+func (s *Server) handleUpload(w http.ResponseWriter, r *http.Request, topic string) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBodySize))
+	if err != nil {
+		s.reject(w, http.StatusBadRequest, "could not read body: %v", err)
+		return
+	}
+	if s.Token != "" && r.Header.Get("Authorization") != "Bearer "+s.Token {
+		s.reject(w, http.StatusUnauthorized, "missing or wrong bearer token")
+		return
+	}
+	if s.Secret != "" {
+		if err := s.verifySignature(r.Header, body); err != nil {
+			s.reject(w, http.StatusUnauthorized, "bad signature: %v", err)
+			return
+		}
+	}
+	// the signature covers the body as sent, so it is decoded after
+	body, status, err := decodeBody(r.Header.Get("Content-Encoding"), body)
+	if err != nil {
+		s.reject(w, status, "%v", err)
+		return
+	}
+	s.accept(w, Payload{Topic: topic, Body: body, Identifier: r.Header.Get("X-Albiondata-Identifier")})
+}
+
+// decodeBody undoes the Content-Encoding of a body. Only gzip is understood,
+// as the standard library has no zstd decoder, so zstd uploads are refused
+// like by an ingest that does not support them.
+func decodeBody(encoding string, body []byte) ([]byte, int, error) {
+	switch encoding {
+	case "", "identity":
+		return body, 0, nil
+	case "gzip":
+		r, err := gzip.NewReader(bytes.NewReader(body))
+		if err != nil {
+			return nil, http.StatusBadRequest, fmt.Errorf("invalid gzip body: %v", err)
+		}
+		decoded, err := io.ReadAll(io.LimitReader(r, maxBodySize+1))
+		if err != nil {
+			return nil, http.StatusBadRequest, fmt.Errorf("invalid gzip body: %v", err)
+		}
+		if len(decoded) > maxBodySize {
+			return nil, http.StatusRequestEntityTooLarge, fmt.Errorf("body is larger than %d bytes uncompressed", maxBodySize)
+		}
+		return decoded, 0, nil
+	}
+	return nil, http.StatusUnsupportedMediaType, fmt.Errorf("unsupported content encoding %q", encoding)
+}
+
+// verifySignature checks the HMAC of a signed upload and that it is neither
+// stale nor a replay
+func (s *Server) verifySignature(header http.Header, body []byte) error {
+	timestamp := header.Get("X-Albiondata-Timestamp")
+	nonce := header.Get("X-Albiondata-Nonce")
+	signature := strings.TrimPrefix(header.Get("X-Albiondata-Signature"), "sha256=")
+
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid timestamp %q", timestamp)
+	}
+	sent := time.Unix(unix, 0)
+	if skew := time.Since(sent); skew > signatureMaxSkew || skew < -signatureMaxSkew {
+		return fmt.Errorf("timestamp %v is too far off", sent)
+	}
+	if nonce == "" {
+		return fmt.Errorf("no nonce")
+	}
+
+	mac := hmac.New(sha256.New, []byte(s.Secret))
+	mac.Write([]byte(timestamp + "." + nonce + "."))
+	mac.Write(body)
+	expected := hex.EncodeToString(mac.Sum(nil))
+	if !hmac.Equal([]byte(signature), []byte(expected)) {
+		return fmt.Errorf("signature does not match")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	for n, seen := range s.nonces {
+		if now.Sub(seen) > 2*signatureMaxSkew {
+			delete(s.nonces, n)
+		}
+	}
+	if _, seen := s.nonces[nonce]; seen {
+		return fmt.Errorf("nonce %v was used before", nonce)
+	}
+	s.nonces[nonce] = now
+	return nil
+}
+
+func (s *Server) accept(w http.ResponseWriter, p Payload) {
+	status, err := validate(p.Topic, p.Body)
+	if err != nil {
+		s.reject(w, status, "invalid %v payload: %v", p.Topic, err)
+		return
+	}
+
+	p.ReceivedAt = time.Now()
+	s.mu.Lock()
+	s.received = append(s.received, p)
+	close(s.arrived)
+	s.arrived = make(chan struct{})
+	s.mu.Unlock()
+
+	log.Infof("Received %v (%d bytes, pow %v)", p.Topic, len(p.Body), p.Pow)
+	if s.OnPayload != nil {
+		s.OnPayload(p)
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) reject(w http.ResponseWriter, status int, format string, args ...interface{}) {
+	s.mu.Lock()
+	s.rejected++
+	s.mu.Unlock()
+
+	message := fmt.Sprintf(format, args...)
+	log.Warnf("Rejected upload: %v", message)
+	http.Error(w, message, status)
+}
+
+func (s *Server) handleReceived(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(s.Received(r.URL.Query().Get("topic")))
+}
+
+// Received returns the accepted payloads of a topic, all of them for ""
+func (s *Server) Received(topic string) []Payload {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	payloads := []Payload{}
+	for _, p := range s.received {
+		if topic == "" || p.Topic == topic {
+			payloads = append(payloads, p)
+		}
+	}
+	return payloads
+}
+
+// Rejected returns how many uploads were turned down
+func (s *Server) Rejected() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.rejected
+}
+
+// WaitFor waits until count payloads of a topic arrived, or the timeout
+// passed. It returns the payloads there are.
+func (s *Server) WaitFor(topic string, count int, timeout time.Duration) ([]Payload, bool) {
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
+
+	for {
+		s.mu.Lock()
+		arrived := s.arrived
+		s.mu.Unlock()
+
+		payloads := s.Received(topic)
+		if len(payloads) >= count {
+			return payloads, true
+		}
+
+		select {
+		case <-arrived:
+		case <-deadline.C:
+			return payloads, false
+		}
+	}
+}
+
+// Reset forgets the received payloads
+func (s *Server) Reset() {
+	s.mu.Lock()
+	s.received = nil
+	s.rejected = 0
+	s.mu.Unlock()
+}
+
+func (s *Server) difficulty() int {
+	if s.Difficulty < 0 {
+		return 0
+	}
+	// the bits of a hex encoded sha256
+	if max := hex.EncodedLen(sha256.Size) * 8; s.Difficulty > max {
+		return max
+	}
+	return s.Difficulty
+}
+
+// Solves tells whether solution answers the challenge key: the bits of the
+// hex encoded sha256 of "aod^solution^key" start with the wanted ones
+func Solves(key, wanted, solution string) bool {
+	hash := sha256.Sum256([]byte("aod^" + solution + "^" + key))
+	return strings.HasPrefix(toBits(hex.EncodeToString(hash[:])), wanted)
+}
+
+func toBits(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		fmt.Fprintf(&b, "%08b", s[i])
+	}
+	return b.String()
+}
+
+func randomHex(n int) string {
+	b := make([]byte, n)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// marketNotification is lib.MarketNotificationUpload with the notification
+// left raw, it is an interface there
+type marketNotification struct {
+	lib.PrivateUpload
+	Type         lib.MarketNotificationType `json:"NotificationType"`
+	Notification json.RawMessage            `json:"Notification"`
+}
+
+// schemas are the payloads of the topics the client uploads to
+var schemas = map[string]func() interface{}{
+	lib.NatsMarketOrdersIngest:    func() interface{} { return &lib.MarketUpload{} },
+	lib.NatsMarketHistoriesIngest: func() interface{} { return &lib.MarketHistoriesUpload{} },
+	lib.NatsGoldPricesIngest:      func() interface{} { return &lib.GoldPricesUpload{} },
+	lib.NatsMapDataIngest:         func() interface{} { return &lib.MapDataUpload{} },
+	lib.NatsSkillData:             func() interface{} { return &lib.SkillsUpload{} },
+	lib.NatsMarketNotifications:   func() interface{} { return &marketNotification{} },
+}
+
+// validate decodes a payload strictly into the struct of its topic
+func validate(topic string, body []byte) (int, error) {
+	schema, ok := schemas[topic]
+	if !ok {
+		return http.StatusNotFound, fmt.Errorf("unknown topic")
+	}
+
+	decoder := json.NewDecoder(strings.NewReader(string(body)))
+	decoder.DisallowUnknownFields()
+	payload := schema()
+	if err := decoder.Decode(payload); err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	switch p := payload.(type) {
+	case *lib.MarketUpload:
+		if len(p.Orders) == 0 {
+			return http.StatusBadRequest, fmt.Errorf("no orders")
+		}
+	case *lib.GoldPricesUpload:
+		if len(p.Prices) != len(p.TimeStamps) {
+			return http.StatusBadRequest, fmt.Errorf("%d prices but %d timestamps", len(p.Prices), len(p.TimeStamps))
+		}
+	case *marketNotification:
+		if p.Type != lib.SalesNotification && p.Type != lib.ExpiryNotification {
+			return http.StatusBadRequest, fmt.Errorf("unknown notification type %q", p.Type)
+		}
+	}
+	return http.StatusOK, nil
+}